@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2025
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HouseholdSpec defines the resources shared by the pets of a household.
+type HouseholdSpec struct {
+	// Stock is the total stock the household can spend on reviving its pets
+	// +kubebuilder:validation:Minimum=0
+	Stock int `json:"stock,omitempty"`
+}
+
+// HouseholdStatus defines the observed state of Household.
+type HouseholdStatus struct {
+	// Spent is the stock spent so far, the household can spend up to spec.stock
+	Spent int `json:"spent,omitempty"`
+
+	// Revivals is the number of pets the household revived
+	Revivals int `json:"revivals,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STOCK",type=integer,JSONPath=`.spec.stock`
+// +kubebuilder:printcolumn:name="SPENT",type=integer,JSONPath=`.status.spent`
+// +kubebuilder:printcolumn:name="REVIVALS",type=integer,JSONPath=`.status.revivals`
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Household is the Schema for the households API. Pets join a household with spec.household.
+type Household struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HouseholdSpec   `json:"spec,omitempty"`
+	Status HouseholdStatus `json:"status,omitempty"`
+}
+
+// Available is the stock the household has left.
+func (h *Household) Available() int {
+	return h.Spec.Stock - h.Status.Spent
+}
+
+// +kubebuilder:object:root=true
+
+// HouseholdList contains a list of Household.
+type HouseholdList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Household `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Household{}, &HouseholdList{})
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2025
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LeaderboardName is the name of the Leaderboard the controller maintains in every namespace with pets.
+const LeaderboardName = "leaderboard"
+
+// LeaderboardSpec is empty, leaderboards are maintained by the controller.
+type LeaderboardSpec struct{}
+
+// CaretakerStatus is how much a user cared for the pets of a namespace.
+type CaretakerStatus struct {
+	// Name is the user name of the caretaker
+	Name string `json:"name"`
+
+	// Feedings is the number of times the caretaker fed a pet
+	Feedings int `json:"feedings,omitempty"`
+
+	// Pettings is the number of times the caretaker petted a pet
+	Pettings int `json:"pettings,omitempty"`
+
+	// Achievements are the badges the caretaker earned
+	// +listType=set
+	// +optional
+	Achievements []string `json:"achievements,omitempty"`
+
+	// LastCareTime is the last time the caretaker fed or petted a pet
+	LastCareTime metav1.Time `json:"lastCareTime,omitempty"`
+}
+
+// SurvivorStatus is the longest survival streak of a pet.
+type SurvivorStatus struct {
+	// Pet is the name of the pet
+	Pet string `json:"pet"`
+
+	// LongestSurvival is the longest time the pet stayed alive in one go
+	LongestSurvival metav1.Duration `json:"longestSurvival"`
+
+	// Alive is true while the pet is alive
+	Alive bool `json:"alive"`
+}
+
+// LeaderboardStatus defines the observed state of Leaderboard.
+type LeaderboardStatus struct {
+	// Caretakers are the users that cared for pets in this namespace, the most caring first
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Caretakers []CaretakerStatus `json:"caretakers,omitempty"`
+
+	// Survivors are the longest surviving pets of this namespace, the longest surviving first
+	// +listType=map
+	// +listMapKey=pet
+	// +optional
+	Survivors []SurvivorStatus `json:"survivors,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="TOP_CARETAKER",type=string,JSONPath=`.status.caretakers[0].name`
+// +kubebuilder:printcolumn:name="TOP_SURVIVOR",type=string,JSONPath=`.status.survivors[0].pet`
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Leaderboard is the Schema for the leaderboards API. It ranks the caretakers
+// and pets of its namespace.
+type Leaderboard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LeaderboardSpec   `json:"spec,omitempty"`
+	Status LeaderboardStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LeaderboardList contains a list of Leaderboard.
+type LeaderboardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Leaderboard `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Leaderboard{}, &LeaderboardList{})
+}
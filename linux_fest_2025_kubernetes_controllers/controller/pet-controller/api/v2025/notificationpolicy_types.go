@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2025
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NotificationPolicySpec defines where pet alerts in a namespace are sent.
+type NotificationPolicySpec struct {
+	// Selector selects the pets this policy applies to. An empty selector selects every pet in the namespace.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Reasons limits the policy to these alert reasons (Dead, NeedLove, NeedFood). Empty means all reasons.
+	// +optional
+	Reasons []string `json:"reasons,omitempty"`
+
+	// RepeatInterval is the minimum time between two notifications for the same pet and reason
+	// +kubebuilder:default="1h"
+	RepeatInterval metav1.Duration `json:"repeatInterval,omitempty"`
+
+	// Webhooks are HTTP endpoints that receive a JSON payload for every alert
+	// +optional
+	Webhooks []WebhookSink `json:"webhooks,omitempty"`
+
+	// SMTP sends every alert as an email
+	// +optional
+	SMTP *SMTPSink `json:"smtp,omitempty"`
+}
+
+// WebhookSink is an HTTP endpoint alerts are POSTed to.
+type WebhookSink struct {
+	// URL is the endpoint the alert is POSTed to
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+
+	// SigningSecretRef selects a key of a Secret in the policy's namespace used to sign the
+	// payload with HMAC-SHA256. The signature is sent in the X-Pet-Signature header.
+	// +optional
+	SigningSecretRef *corev1.SecretKeySelector `json:"signingSecretRef,omitempty"`
+
+	// MaxRetries is the number of times a failed delivery is retried with exponential backoff
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	MaxRetries int `json:"maxRetries,omitempty"`
+}
+
+// SMTPSink is a mail server alerts are sent through.
+type SMTPSink struct {
+	// Host is the SMTP server host
+	// +kubebuilder:validation:Required
+	Host string `json:"host"`
+
+	// Port is the SMTP server port
+	// +kubebuilder:default=587
+	Port int `json:"port,omitempty"`
+
+	// From is the sender address
+	// +kubebuilder:validation:Required
+	From string `json:"from"`
+
+	// To are the recipient addresses
+	// +kubebuilder:validation:MinItems=1
+	To []string `json:"to"`
+
+	// CredentialsSecretRef names a Secret in the policy's namespace with "username" and "password" keys
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// NotificationPolicyStatus defines the observed state of NotificationPolicy.
+type NotificationPolicyStatus struct {
+	// LastSentTime is the last time an alert was delivered by this policy
+	LastSentTime metav1.Time `json:"lastSentTime,omitempty"`
+
+	// Sent is the number of alerts delivered by this policy
+	Sent int `json:"sent,omitempty"`
+
+	// Failed is the number of alerts that could not be delivered by this policy
+	Failed int `json:"failed,omitempty"`
+
+	// LastError is the error of the last failed delivery
+	LastError string `json:"lastError,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="SENT",type=integer,JSONPath=`.status.sent`
+// +kubebuilder:printcolumn:name="FAILED",type=integer,JSONPath=`.status.failed`
+// +kubebuilder:printcolumn:name="LAST_SENT",type=date,JSONPath=`.status.lastSentTime`
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// NotificationPolicy is the Schema for the notificationpolicies API.
+type NotificationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NotificationPolicySpec   `json:"spec,omitempty"`
+	Status NotificationPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NotificationPolicyList contains a list of NotificationPolicy.
+type NotificationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NotificationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NotificationPolicy{}, &NotificationPolicyList{})
+}
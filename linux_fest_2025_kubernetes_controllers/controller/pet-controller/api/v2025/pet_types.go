@@ -43,6 +43,18 @@ type PetSpec struct {
 	// DecayInterval is the interval in which the love and food is decayed for this pet
 	// +kubebuilder:default="10s"
 	DecayInterval metav1.Duration `json:"decayInterval,omitempty"`
+
+	// Friends are the names of pets in the same namespace this pet is friends with
+	// +optional
+	Friends []string `json:"friends,omitempty"`
+
+	// Household is the name of the Household in the same namespace that pays for reviving this pet
+	// +optional
+	Household string `json:"household,omitempty"`
+
+	// Species selects the species profile of the controller configuration, such as its rate limits
+	// +optional
+	Species string `json:"species,omitempty"`
 }
 
 // PetStatus defines the observed state of Pet.
@@ -60,6 +72,15 @@ type PetStatus struct {
 	// +kubebuilder:validation:Maximum=100
 	Love int `json:"love,omitempty" `
 
+	// Health is the health of the pet, it declines while the pet is neglected and the pet dies when it reaches zero
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Health int `json:"health,omitempty"`
+
+	// NeglectedTicks is the number of consecutive decay intervals the pet's food or love stayed below the neglect thresholds
+	// +optional
+	NeglectedTicks int `json:"neglectedTicks,omitempty"`
+
 	// FedTime is the last time the pet was fed
 	FedTime metav1.Time `json:"fedTime,omitempty"`
 
@@ -71,12 +92,114 @@ type PetStatus struct {
 
 	// Initialized
 	Initialized bool `json:"initialized"`
+
+	// Stage is the life stage of the pet, it is derived from its lifetime and the care it received
+	// +optional
+	Stage PetStage `json:"stage,omitempty"`
+
+	// Ticks is the number of decay intervals the pet has lived through
+	// +optional
+	Ticks int `json:"ticks,omitempty"`
+
+	// CaredTicks is the number of decay intervals the pet ended neither hungry nor lonely
+	// +optional
+	CaredTicks int `json:"caredTicks,omitempty"`
+
+	// Revivals is the number of times the pet was revived
+	// +optional
+	Revivals int `json:"revivals,omitempty"`
+
+	// LastRevivalTime is the last time the pet was revived
+	// +optional
+	LastRevivalTime metav1.Time `json:"lastRevivalTime,omitempty"`
+
+	// LongestSurvival is the longest time the pet stayed alive in one go
+	// +optional
+	LongestSurvival metav1.Duration `json:"longestSurvival,omitempty"`
+
+	// Achievements are the badges the pet earned
+	// +listType=set
+	// +optional
+	Achievements []string `json:"achievements,omitempty"`
+
+	// MournedFriends are the dead friends whose loss the pet has already grieved
+	// +optional
+	MournedFriends []string `json:"mournedFriends,omitempty"`
+
+	// RejectedActions is the number of feed and pet requests dropped because they exceeded a rate limit
+	// +optional
+	RejectedActions int `json:"rejectedActions,omitempty"`
+
+	// CooldownUntil is when the pet accepts feed and pet requests again after its last rejected one
+	// +optional
+	CooldownUntil metav1.Time `json:"cooldownUntil,omitempty"`
+
+	// Overfeedings is the number of times the pet was fed beyond its overfeeding cap
+	// +optional
+	Overfeedings int `json:"overfeedings,omitempty"`
+
+	// OverfedUntil is when the pet stops being overfed
+	// +optional
+	OverfedUntil metav1.Time `json:"overfedUntil,omitempty"`
+
+	// Conditions are the Hungry, Lonely, Overfed, Sick and Dead conditions of the pet
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// CareScore is the percentage of decay intervals the pet ended neither hungry nor lonely.
+func (s *PetStatus) CareScore() int {
+	if s.Ticks == 0 {
+		return 100
+	}
+	return s.CaredTicks * 100 / s.Ticks
 }
 
+// RequesterAnnotation is stamped on a pet by the admission webhook with the
+// user that set its feed, pet or revive annotation.
+const RequesterAnnotation = "linuxfest.example.com/requester"
+
+// RestoringAnnotation is set on a pet while petctl restores its status from an
+// archive. The controller leaves such pets alone until it is removed.
+const RestoringAnnotation = "linuxfest.example.com/restoring"
+
+// PetStage is a life stage of a pet.
+// +kubebuilder:validation:Enum=Baby;Adult;Senior
+type PetStage string
+
+// Life stages a pet goes through, in order.
+const (
+	PetStageBaby   PetStage = "Baby"
+	PetStageAdult  PetStage = "Adult"
+	PetStageSenior PetStage = "Senior"
+)
+
+// Condition types reported in [PetStatus.Conditions].
+const (
+	// PetConditionHungry is true while the pet's food is below the hungry threshold
+	PetConditionHungry = "Hungry"
+
+	// PetConditionLonely is true while the pet has no love left
+	PetConditionLonely = "Lonely"
+
+	// PetConditionSick is true while the pet's health is below the sick threshold
+	PetConditionSick = "Sick"
+
+	// PetConditionDead is true once the pet's health reached zero
+	PetConditionDead = "Dead"
+
+	// PetConditionOverfed is true for a while after the pet was fed beyond its overfeeding cap
+	PetConditionOverfed = "Overfed"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="FOOD",type=integer,JSONPath=`.status.food`
 // +kubebuilder:printcolumn:name="LOVE",type=integer,JSONPath=`.status.love`
+// +kubebuilder:printcolumn:name="HEALTH",type=integer,JSONPath=`.status.health`
+// +kubebuilder:printcolumn:name="STAGE",type=string,JSONPath=`.status.stage`
 // +kubebuilder:printcolumn:name="FED_TIME",type=date,JSONPath=`.status.fedTime`
 // +kubebuilder:printcolumn:name="PET_TIME",type=date,JSONPath=`.status.petTime`
 // +kubebuilder:printcolumn:name="MODIFIED_TIME",type=date,JSONPath=`.status.modifiedTime`
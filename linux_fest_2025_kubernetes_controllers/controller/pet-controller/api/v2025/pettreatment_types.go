@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2025
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PetTreatmentSpec defines the treatment given to a pet.
+type PetTreatmentSpec struct {
+	// PetName is the name of the pet in the same namespace that is treated
+	// +kubebuilder:validation:Required
+	PetName string `json:"petName"`
+
+	// Health is the amount of health the treatment restores
+	// +kubebuilder:default=50
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Health int `json:"health,omitempty"`
+}
+
+// PetTreatmentPhase is the outcome of a treatment.
+// +kubebuilder:validation:Enum=Applied;Failed
+type PetTreatmentPhase string
+
+// Phases of a treatment, a treatment without a phase has not been applied yet.
+const (
+	PetTreatmentApplied PetTreatmentPhase = "Applied"
+	PetTreatmentFailed  PetTreatmentPhase = "Failed"
+)
+
+// PetTreatmentStatus defines the observed state of PetTreatment.
+type PetTreatmentStatus struct {
+	// Phase is the outcome of the treatment, it is empty until the treatment is applied
+	// +optional
+	Phase PetTreatmentPhase `json:"phase,omitempty"`
+
+	// AppliedTime is the time the treatment was applied or failed
+	// +optional
+	AppliedTime metav1.Time `json:"appliedTime,omitempty"`
+
+	// Message explains the phase
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="PET",type=string,JSONPath=`.spec.petName`
+// +kubebuilder:printcolumn:name="HEALTH",type=integer,JSONPath=`.spec.health`
+// +kubebuilder:printcolumn:name="PHASE",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// PetTreatment is the Schema for the pettreatments API. A treatment heals its
+// pet once and is kept as a record afterwards.
+type PetTreatment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PetTreatmentSpec   `json:"spec,omitempty"`
+	Status PetTreatmentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PetTreatmentList contains a list of PetTreatment.
+type PetTreatmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PetTreatment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PetTreatment{}, &PetTreatmentList{})
+}
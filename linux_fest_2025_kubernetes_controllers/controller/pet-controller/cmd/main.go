@@ -17,18 +17,25 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -36,7 +43,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/config"
 	"github.com/itzloop/pet-controller/internal/controller"
+	"github.com/itzloop/pet-controller/internal/notifier"
+	"github.com/itzloop/pet-controller/internal/sharding"
+	"github.com/itzloop/pet-controller/internal/statsapi"
+	"github.com/itzloop/pet-controller/internal/tracing"
+	webhookv2025 "github.com/itzloop/pet-controller/internal/webhook/v2025"
+	"github.com/itzloop/pet-controller/pkg/history"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -58,6 +72,19 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var leaderElectionID string
+	var watchNamespaces string
+	var petSelector string
+	var configFile string
+	var statsAddr string
+	var statsTokenFile string
+	var decayOpts controller.DecayOptions
+	reconcileOpts := controller.DefaultReconcileOptions()
+	var maxDecayLag time.Duration
+	var dryRun bool
+	var shards int
+	var shardNamespace string
+	var shardIdentity string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -65,6 +92,50 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&leaderElectionID, "leader-election-id", "3844e53a.example.com",
+		"The name of the lease used for leader election. "+
+			"Instances watching different pets in the same namespace need distinct IDs.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", "",
+		"Comma separated list of namespaces to watch pets in. Leave empty to watch all namespaces.")
+	flag.StringVar(&petSelector, "pet-selector", "",
+		"Label selector restricting the pets this instance reconciles, e.g. team=blue.")
+	flag.StringVar(&configFile, "config", "",
+		"Path to a PetControllerConfiguration file. It is reloaded whenever it changes. "+
+			"Leave empty to use the default configuration.")
+	flag.StringVar(&statsAddr, "stats-bind-address", "0",
+		"The address the read-only pet stats API binds to, e.g. :8082. Leave as 0 to disable it.")
+	flag.StringVar(&statsTokenFile, "stats-token-file", "",
+		"Path to a file holding the bearer token clients of the pet stats API must present.")
+	flag.IntVar(&decayOpts.Workers, "decay-workers", 10,
+		"The number of pets decayed at the same time.")
+	flag.IntVar(&decayOpts.BatchSize, "decay-batch-size", 500,
+		"The most due pets taken from the decay queue at once.")
+	flag.DurationVar(&decayOpts.Resolution, "decay-resolution", 100*time.Millisecond,
+		"How often the decay queue is checked for due pets.")
+	flag.DurationVar(&maxDecayLag, "max-decay-lag", 5*time.Minute,
+		"The liveness probe fails once a pet is overdue to decay for longer than this.")
+	flag.IntVar(&reconcileOpts.MaxConcurrentReconciles, "max-concurrent-reconciles", reconcileOpts.MaxConcurrentReconciles,
+		"The number of pets reconciled at the same time.")
+	flag.DurationVar(&reconcileOpts.BaseDelay, "reconcile-base-delay", reconcileOpts.BaseDelay,
+		"The delay before a pet whose reconcile failed is retried, it doubles on every failure in a row.")
+	flag.DurationVar(&reconcileOpts.MaxDelay, "reconcile-max-delay", reconcileOpts.MaxDelay,
+		"The longest delay before a pet whose reconcile failed is retried.")
+	flag.Float64Var(&reconcileOpts.QPS, "reconcile-qps", reconcileOpts.QPS,
+		"How many pets per second may be queued for reconciling overall.")
+	flag.IntVar(&reconcileOpts.Burst, "reconcile-burst", reconcileOpts.Burst,
+		"How many pets may be queued at once above --reconcile-qps.")
+	flag.BoolVar(reconcileOpts.RecoverPanic, "recover-panic", *reconcileOpts.RecoverPanic,
+		"If set, a panicking reconcile is retried like a failed one instead of crashing the manager.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"If set, the controller logs the changes it would make to pets as diffs instead of making them. "+
+			"Use it to shadow a new release next to the active one.")
+	flag.IntVar(&shards, "shards", 0,
+		"Split the pets into this many shards shared by every replica through Leases. "+
+			"Leave as 0 to reconcile every pet. Can't be combined with --leader-elect.")
+	flag.StringVar(&shardNamespace, "shard-namespace", "",
+		"The namespace of the shard Leases. Defaults to the namespace of the pod.")
+	flag.StringVar(&shardIdentity, "shard-identity", "",
+		"The unique name of this replica among the shard owners. Defaults to the hostname.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
@@ -73,10 +144,20 @@ func main() {
 		Development: true,
 	}
 	opts.BindFlags(flag.CommandLine)
+	var tracingOpts tracing.Options
+	tracingOpts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	ctx := ctrl.SetupSignalHandler()
+
+	shutdownTracing, err := tracing.Setup(ctx, tracingOpts)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -118,13 +199,36 @@ func main() {
 		// this setup is not recommended for production.
 	}
 
+	if shards > 0 && enableLeaderElection {
+		setupLog.Error(nil, "--shards can't be combined with --leader-elect, every shard owner has to run")
+		os.Exit(1)
+	}
+
+	if shards > 0 && dryRun {
+		setupLog.Error(nil, "--shards can't be combined with --dry-run, the shard Leases would still be taken")
+		os.Exit(1)
+	}
+
+	configStore, err := config.NewStore(configFile)
+	if err != nil {
+		setupLog.Error(err, "unable to load configuration", "path", configFile)
+		os.Exit(1)
+	}
+
+	cacheOpts, err := cacheOptions(watchNamespaces, petSelector)
+	if err != nil {
+		setupLog.Error(err, "unable to configure cache")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  cacheOpts,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "3844e53a.example.com",
+		LeaderElectionID:       leaderElectionID,
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -142,27 +246,180 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = (&controller.PetReconciler{
+	if err := mgr.Add(configStore); err != nil {
+		setupLog.Error(err, "unable to watch configuration")
+		os.Exit(1)
+	}
+
+	petNotifier := notifier.NewPetNotifier(mgr.GetClient(), mgr.GetAPIReader())
+	if err := mgr.Add(petNotifier); err != nil {
+		setupLog.Error(err, "unable to set up notifier")
+		os.Exit(1)
+	}
+
+	var coordinator *sharding.Coordinator
+	if shards > 0 {
+		coordinator, err = shardCoordinator(mgr, shards, shardNamespace, shardIdentity, leaderElectionID)
+		if err != nil {
+			setupLog.Error(err, "unable to set up sharding")
+			os.Exit(1)
+		}
+		if err := mgr.Add(coordinator); err != nil {
+			setupLog.Error(err, "unable to set up sharding")
+			os.Exit(1)
+		}
+	}
+
+	if statsAddr != "0" {
+		token, err := os.ReadFile(statsTokenFile)
+		if err != nil {
+			setupLog.Error(err, "unable to read stats API token", "path", statsTokenFile)
+			os.Exit(1)
+		}
+		statsServer := &statsapi.Server{
+			Addr:  statsAddr,
+			Token: strings.TrimSpace(string(token)),
+			Cache: mgr.GetCache(),
+		}
+		if err := mgr.Add(statsServer); err != nil {
+			setupLog.Error(err, "unable to set up stats API")
+			os.Exit(1)
+		}
+	}
+
+	// nolint:goconst
+	enableWebhooks := os.Getenv("ENABLE_WEBHOOKS") != "false"
+
+	// 🏅 Care is counted by the pet controller and written by the leaderboard controller
+	care := &controller.CareTally{}
+
+	petReconciler := &controller.PetReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Notifier: petNotifier,
+		Config:   configStore,
+		History:  &history.Recorder{Client: mgr.GetClient(), Scheme: mgr.GetScheme()},
+		Decay:    decayOpts,
+		Options:  reconcileOpts,
+		DryRun:   dryRun,
+		Shards:   coordinator,
+		Care:     care,
+
+		// 🙋 Only the webhook vouches for the requester annotation
+		TrustRequester: enableWebhooks,
+	}
+	if err = petReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Pet")
+		os.Exit(1)
+	}
+	if err = (&controller.LeaderboardReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
+		Care:   care,
+		DryRun: dryRun,
+		Shards: coordinator,
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Pet")
+		setupLog.Error(err, "unable to create controller", "controller", "Leaderboard")
 		os.Exit(1)
 	}
+	if enableWebhooks {
+		if err = webhookv2025.SetupPetWebhookWithManager(mgr, configStore); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Pet")
+			os.Exit(1)
+		}
+		if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+			setupLog.Error(err, "unable to set up ready check")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
+	if err := mgr.AddHealthzCheck("decay-lag", petReconciler.DecayLagChecker(maxDecayLag)); err != nil {
+		setupLog.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
 	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddReadyzCheck("informers", controller.CacheSyncedChecker(mgr.GetCache(), time.Second)); err != nil {
+		setupLog.Error(err, "unable to set up ready check")
+		os.Exit(1)
+	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	// Flush any spans still buffered by the exporter before exiting.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		setupLog.Error(err, "unable to flush traces")
+	}
+}
+
+// shardCoordinator returns the coordinator splitting the pets into shards between
+// the replicas of group. The namespace and identity default to those of the pod.
+func shardCoordinator(mgr ctrl.Manager, shards int, namespace, identity, group string) (*sharding.Coordinator, error) {
+	if namespace == "" {
+		data, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+		if err != nil {
+			return nil, fmt.Errorf("--shard-namespace is required outside a cluster: %w", err)
+		}
+		namespace = strings.TrimSpace(string(data))
+	}
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("--shard-identity is required without a hostname: %w", err)
+		}
+		identity = hostname
+	}
+
+	return sharding.NewCoordinator(mgr.GetClient(), mgr.GetAPIReader(), sharding.Options{
+		Namespace: namespace,
+		Group:     group,
+		Identity:  identity,
+		Shards:    shards,
+	})
+}
+
+// cacheOptions restricts the manager cache to the comma separated namespaces and
+// to the pets matching selector, so several instances can split the pets between them.
+func cacheOptions(namespaces, selector string) (cache.Options, error) {
+	var opts cache.Options
+
+	for _, ns := range strings.Split(namespaces, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "" {
+			continue
+		}
+		if opts.DefaultNamespaces == nil {
+			opts.DefaultNamespaces = map[string]cache.Config{}
+		}
+		opts.DefaultNamespaces[ns] = cache.Config{}
+	}
+
+	if selector != "" {
+		sel, err := labels.Parse(selector)
+		if err != nil {
+			return opts, fmt.Errorf("invalid pet selector %q: %w", selector, err)
+		}
+		opts.ByObject = map[client.Object]cache.ByObject{
+			&linuxfestv2025.Pet{}: {Label: sel},
+		}
+	}
+
+	if len(opts.DefaultNamespaces) > 0 || selector != "" {
+		setupLog.Info("restricting watched pets", "namespaces", namespaces, "selector", selector)
+	}
+
+	return opts, nil
 }
@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/yaml"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+)
+
+var _ = Describe("cacheOptions", func() {
+	It("should watch every namespace by default", func() {
+		opts, err := cacheOptions("", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(opts.DefaultNamespaces).To(BeNil())
+		Expect(opts.ByObject).To(BeNil())
+	})
+
+	It("should watch every listed namespace", func() {
+		opts, err := cacheOptions("team-a, team-b,,team-c ", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(opts.DefaultNamespaces).To(Equal(map[string]cache.Config{
+			"team-a": {},
+			"team-b": {},
+			"team-c": {},
+		}))
+	})
+
+	It("should ignore blank namespaces", func() {
+		opts, err := cacheOptions(" , ", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(opts.DefaultNamespaces).To(BeNil())
+	})
+
+	It("should only watch pets matching the selector", func() {
+		opts, err := cacheOptions("team-a", "tier=gold,team!=b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(opts.DefaultNamespaces).To(HaveKey("team-a"))
+		Expect(opts.ByObject).To(HaveLen(1))
+
+		for obj, byObject := range opts.ByObject {
+			Expect(obj).To(BeAssignableToTypeOf(&linuxfestv2025.Pet{}))
+			Expect(byObject.Label.Matches(labels.Set{"tier": "gold", "team": "a"})).To(BeTrue())
+			Expect(byObject.Label.Matches(labels.Set{"tier": "gold", "team": "b"})).To(BeFalse())
+		}
+	})
+
+	It("should reject an invalid selector", func() {
+		_, err := cacheOptions("", "tier in (gold")
+		Expect(err).To(MatchError(ContainSubstring(`invalid pet selector "tier in (gold"`)))
+	})
+})
+
+// rules returns the rules of the RBAC role in path.
+func rules(path string) []rbacv1.PolicyRule {
+	GinkgoHelper()
+
+	data, err := os.ReadFile(path)
+	Expect(err).NotTo(HaveOccurred())
+
+	var role rbacv1.Role
+	Expect(yaml.Unmarshal(data, &role)).To(Succeed())
+	return role.Rules
+}
+
+var _ = Describe("namespaced RBAC", func() {
+	// config/namespaced turns the manager ClusterRole into a Role and deploys it
+	// next to the leader election Role, so together they must cover everything
+	// the manager touches in its own namespace.
+	var granted []rbacv1.PolicyRule
+
+	BeforeEach(func() {
+		dir := filepath.Join("..", "config", "rbac")
+		granted = append(rules(filepath.Join(dir, "role.yaml")), rules(filepath.Join(dir, "leader_election_role.yaml"))...)
+	})
+
+	allows := func(group, resource, verb string) bool {
+		return slices.ContainsFunc(granted, func(rule rbacv1.PolicyRule) bool {
+			return slices.Contains(rule.APIGroups, group) &&
+				slices.Contains(rule.Resources, resource) &&
+				slices.Contains(rule.Verbs, verb)
+		})
+	}
+
+	DescribeTable("should grant the manager",
+		func(group, resource string, verbs ...string) {
+			for _, verb := range verbs {
+				Expect(allows(group, resource, verb)).To(BeTrue(), "%s %s.%s", verb, resource, group)
+			}
+		},
+		Entry("pets", linuxfestv2025.GroupVersion.Group, "pets", "get", "list", "watch", "update", "patch"),
+		Entry("pet status", linuxfestv2025.GroupVersion.Group, "pets/status", "get", "update", "patch"),
+		Entry("households", linuxfestv2025.GroupVersion.Group, "households", "get", "list", "watch"),
+		Entry("household status", linuxfestv2025.GroupVersion.Group, "households/status", "update"),
+		Entry("leaderboards", linuxfestv2025.GroupVersion.Group, "leaderboards", "get", "list", "watch", "create"),
+		Entry("leaderboard status", linuxfestv2025.GroupVersion.Group, "leaderboards/status", "update"),
+		Entry("config maps", "", "configmaps", "get", "list", "watch", "create", "update"),
+		Entry("leases", "coordination.k8s.io", "leases", "get", "list", "watch", "create", "update"),
+		Entry("events", "", "events", "create", "patch"),
+	)
+
+	It("should grant the shard Leases without the leader election Role", func() {
+		// 🧩 --shard-namespace may be another namespace than the leader election Role's
+		granted = rules(filepath.Join("..", "config", "rbac", "role.yaml"))
+		for _, verb := range []string{"get", "list", "watch", "create", "update", "delete"} {
+			Expect(allows("coordination.k8s.io", "leases", verb)).To(BeTrue(), verb)
+		}
+	})
+})
@@ -0,0 +1,242 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command petctl manages pets from outside the cluster.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/backup"
+	"github.com/itzloop/pet-controller/internal/config"
+	"github.com/itzloop/pet-controller/internal/simulate"
+)
+
+func main() {
+	root := &cobra.Command{
+		Use:           "petctl",
+		Short:         "Manage LinuxFest pets",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	// 🔑 --kubeconfig is registered on the standard flag set by controller-runtime
+	root.PersistentFlags().AddGoFlagSet(flag.CommandLine)
+	root.AddCommand(exportCommand(), importCommand(), simulateCommand())
+
+	if err := root.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+// newClient returns a client for the cluster of the current kubeconfig context.
+func newClient() (client.Client, error) {
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := linuxfestv2025.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme})
+}
+
+func exportCommand() *cobra.Command {
+	var (
+		namespace     string
+		allNamespaces bool
+		output        string
+		configFile    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export pets including their status to an archive",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+
+			var opts []client.ListOption
+			if !allNamespaces {
+				opts = append(opts, client.InNamespace(namespace))
+			}
+			cfg := config.Default()
+			if configFile != "" {
+				if cfg, err = config.Load(configFile); err != nil {
+					return err
+				}
+			}
+			archive, err := backup.Export(cmd.Context(), c, cfg.Annotations, opts...)
+			if err != nil {
+				return err
+			}
+
+			w := cmd.OutOrStdout()
+			if output != "" && output != "-" {
+				f, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+			if err := backup.Encode(w, archive); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "📦 Exported %d pets\n", len(archive.Pets))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "The namespace to export pets from.")
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Export pets from every namespace.")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "The file the archive is written to. Defaults to stdout.")
+	cmd.Flags().StringVar(&configFile, "config", "", "The controller configuration file naming the action annotations left out of the archive. Defaults to the built-in configuration.")
+	return cmd
+}
+
+func importCommand() *cobra.Command {
+	var (
+		file       string
+		onConflict string
+		dryRun     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Restore pets and their status from an archive",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			policy, err := backup.ParseConflictPolicy(onConflict)
+			if err != nil {
+				return err
+			}
+
+			var r io.Reader = cmd.InOrStdin()
+			if file != "-" {
+				f, err := os.Open(file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				r = f
+			}
+			archive, err := backup.Decode(r)
+			if err != nil {
+				return err
+			}
+
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			restorer := &backup.Restorer{Client: c, Policy: policy, DryRun: dryRun}
+			results, err := restorer.Restore(cmd.Context(), archive)
+
+			suffix := ""
+			if dryRun {
+				suffix = " (dry run)"
+			}
+			for _, result := range results {
+				fmt.Fprintf(cmd.OutOrStdout(), "pet %s/%s %s as %s%s\n",
+					result.Source.Namespace, result.Source.Name, result.Action, result.Name, suffix)
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "filename", "f", "", "The archive to restore, or - for stdin.")
+	cmd.Flags().StringVar(&onConflict, "on-conflict", string(backup.ConflictSkip),
+		"What to do with pets that already exist: skip, overwrite or rename.")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate the restore against the cluster without changing anything.")
+	_ = cmd.MarkFlagRequired("filename")
+	return cmd
+}
+
+func simulateCommand() *cobra.Command {
+	var (
+		file       string
+		configFile string
+		opts       simulate.Options
+		report     simulate.ReportOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "Predict how a pet fares offline, without a cluster",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var r io.Reader = cmd.InOrStdin()
+			if file != "-" {
+				f, err := os.Open(file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				r = f
+			}
+			pet, err := simulate.Load(r)
+			if err != nil {
+				return err
+			}
+
+			cfg := config.Default()
+			if configFile != "" {
+				if cfg, err = config.Load(configFile); err != nil {
+					return err
+				}
+			}
+
+			result, err := simulate.Run(pet, cfg.Rules(pet.Spec.Species), opts)
+			if err != nil {
+				return err
+			}
+			return simulate.WriteReport(cmd.OutOrStdout(), result, report)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "filename", "f", "", "The Pet manifest to simulate, or - for stdin.")
+	cmd.Flags().StringVar(&configFile, "config", "", "The controller configuration file the pet plays by. Defaults to the built-in configuration.")
+	cmd.Flags().DurationVar(&opts.Duration, "duration", 24*time.Hour, "How long to simulate the pet for.")
+	cmd.Flags().DurationVar(&opts.FeedEvery, "feed-every", 0, "Feed the pet at this interval. Zero never feeds it.")
+	cmd.Flags().IntVar(&opts.FeedAmount, "feed-amount", 30, "The food given on every feeding.")
+	cmd.Flags().DurationVar(&opts.PetEvery, "pet-every", 0, "Pet the pet at this interval. Zero never pets it.")
+	cmd.Flags().IntVar(&opts.PetAmount, "pet-amount", 30, "The love given on every petting.")
+	cmd.Flags().IntVar(&report.Width, "width", 60, "The width of the chart in columns.")
+	cmd.Flags().IntVar(&report.TimelineLimit, "timeline-limit", 50, "The number of timeline events shown. Zero shows all of them.")
+	_ = cmd.MarkFlagRequired("filename")
+	return cmd
+}
@@ -0,0 +1,334 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backup exports pets together with their status into versioned
+// archives and restores them without the controller resetting their state.
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/config"
+)
+
+const (
+	// APIVersion is the version of the archive format written by Export.
+	APIVersion = "backup.linuxfest.example.com/v1"
+
+	// Kind is the kind of an archive document.
+	Kind = "PetArchive"
+
+	// maxRenames bounds the search for a free name when renaming conflicting pets.
+	maxRenames = 100
+)
+
+// Archive is a snapshot of pets including their status.
+type Archive struct {
+	APIVersion string               `json:"apiVersion"`
+	Kind       string               `json:"kind"`
+	ExportedAt metav1.Time          `json:"exportedAt"`
+	Pets       []linuxfestv2025.Pet `json:"pets"`
+}
+
+// Export returns an archive of the pets matching opts. Pending actions on the
+// annotations of actions are left out, so restoring a pet doesn't feed, pet or
+// revive it again.
+func Export(ctx context.Context, c client.Reader, actions config.AnnotationConfig, opts ...client.ListOption) (*Archive, error) {
+	var pets linuxfestv2025.PetList
+	if err := c.List(ctx, &pets, opts...); err != nil {
+		return nil, err
+	}
+
+	archive := &Archive{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		ExportedAt: metav1.Now(),
+		Pets:       make([]linuxfestv2025.Pet, 0, len(pets.Items)),
+	}
+	for i := range pets.Items {
+		archive.Pets = append(archive.Pets, portable(&pets.Items[i], actions))
+	}
+	return archive, nil
+}
+
+// portable strips the cluster specific metadata and pending actions of pet.
+func portable(pet *linuxfestv2025.Pet, actions config.AnnotationConfig) linuxfestv2025.Pet {
+	annotations := maps.Clone(pet.Annotations)
+	for _, key := range []string{
+		linuxfestv2025.RestoringAnnotation,
+		linuxfestv2025.RequesterAnnotation,
+		actions.Feed,
+		actions.Pet,
+		actions.Revive,
+	} {
+		delete(annotations, key)
+	}
+
+	return linuxfestv2025.Pet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: linuxfestv2025.GroupVersion.String(),
+			Kind:       "Pet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   pet.Namespace,
+			Name:        pet.Name,
+			Labels:      pet.Labels,
+			Annotations: annotations,
+		},
+		Spec:   pet.Spec,
+		Status: pet.Status,
+	}
+}
+
+// Encode writes archive to w as YAML.
+func Encode(w io.Writer, archive *Archive) error {
+	data, err := yaml.Marshal(archive)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Decode reads an archive from r and checks its version.
+func Decode(r io.Reader) (*Archive, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var archive Archive
+	if err := yaml.UnmarshalStrict(data, &archive); err != nil {
+		return nil, fmt.Errorf("decoding archive: %w", err)
+	}
+	if archive.APIVersion != APIVersion || archive.Kind != Kind {
+		return nil, fmt.Errorf("unsupported archive %s %s, expected %s %s",
+			archive.APIVersion, archive.Kind, APIVersion, Kind)
+	}
+	return &archive, nil
+}
+
+// ConflictPolicy decides what happens to archived pets that already exist.
+type ConflictPolicy string
+
+const (
+	// ConflictSkip leaves existing pets untouched.
+	ConflictSkip ConflictPolicy = "skip"
+
+	// ConflictOverwrite replaces existing pets with the archived ones.
+	ConflictOverwrite ConflictPolicy = "overwrite"
+
+	// ConflictRename restores the archived pet under a new name.
+	ConflictRename ConflictPolicy = "rename"
+)
+
+// ParseConflictPolicy returns the conflict policy named s.
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	switch policy := ConflictPolicy(s); policy {
+	case ConflictSkip, ConflictOverwrite, ConflictRename:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown conflict policy %q, must be one of skip, overwrite or rename", s)
+	}
+}
+
+// Action is what happened to an archived pet during a restore.
+type Action string
+
+// Actions reported by Restore.
+const (
+	ActionCreated     Action = "created"
+	ActionOverwritten Action = "overwritten"
+	ActionRenamed     Action = "renamed"
+	ActionSkipped     Action = "skipped"
+)
+
+// Result describes the restore of a single archived pet.
+type Result struct {
+	// Source is the namespace and name of the pet in the archive.
+	Source client.ObjectKey
+
+	// Name is the name the pet was restored as.
+	Name string
+
+	Action Action
+}
+
+// Restorer recreates archived pets with their status.
+type Restorer struct {
+	client.Client
+
+	// Policy decides what happens to pets that already exist.
+	Policy ConflictPolicy
+
+	// DryRun validates the restore against the API server without persisting anything.
+	DryRun bool
+}
+
+// Restore restores every pet of archive. Pets that fail to restore do not stop
+// the others; their errors are joined in the returned error.
+func (r *Restorer) Restore(ctx context.Context, archive *Archive) ([]Result, error) {
+	var (
+		results []Result
+		errs    []error
+	)
+	for i := range archive.Pets {
+		pet := &archive.Pets[i]
+
+		result, err := r.restore(ctx, pet)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("pet %s/%s: %w", pet.Namespace, pet.Name, err))
+			continue
+		}
+		results = append(results, result)
+	}
+	return results, errors.Join(errs...)
+}
+
+func (r *Restorer) restore(ctx context.Context, saved *linuxfestv2025.Pet) (Result, error) {
+	result := Result{Source: client.ObjectKeyFromObject(saved), Name: saved.Name}
+
+	var existing linuxfestv2025.Pet
+	err := r.Get(ctx, result.Source, &existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		result.Action = ActionCreated
+		return result, r.create(ctx, saved, saved.Name)
+	case err != nil:
+		return result, err
+	}
+
+	switch r.Policy {
+	case ConflictOverwrite:
+		result.Action = ActionOverwritten
+		return result, r.overwrite(ctx, &existing, saved)
+	case ConflictRename:
+		name, err := r.freeName(ctx, saved)
+		if err != nil {
+			return result, err
+		}
+		result.Name = name
+		result.Action = ActionRenamed
+		return result, r.create(ctx, saved, name)
+	default:
+		result.Action = ActionSkipped
+		return result, nil
+	}
+}
+
+// create creates saved as name and restores its status.
+func (r *Restorer) create(ctx context.Context, saved *linuxfestv2025.Pet, name string) error {
+	pet := &linuxfestv2025.Pet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   saved.Namespace,
+			Name:        name,
+			Labels:      saved.Labels,
+			Annotations: restoring(saved.Annotations),
+		},
+		Spec: saved.Spec,
+	}
+	var opts []client.CreateOption
+	if r.DryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := r.Create(ctx, pet, opts...); err != nil {
+		return err
+	}
+	if r.DryRun {
+		return nil
+	}
+	return r.finish(ctx, pet, saved.Status)
+}
+
+// overwrite replaces existing with saved.
+func (r *Restorer) overwrite(ctx context.Context, existing, saved *linuxfestv2025.Pet) error {
+	pet := existing.DeepCopy()
+	pet.Labels = saved.Labels
+	pet.Annotations = restoring(saved.Annotations)
+	pet.Spec = saved.Spec
+	var opts []client.UpdateOption
+	if r.DryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := r.Update(ctx, pet, opts...); err != nil {
+		return err
+	}
+	if r.DryRun {
+		return nil
+	}
+	return r.finish(ctx, pet, saved.Status)
+}
+
+// finish writes status to pet and hands it back to the controller. Pets that
+// were never initialized get an empty status so the controller initializes them.
+func (r *Restorer) finish(ctx context.Context, pet *linuxfestv2025.Pet, status linuxfestv2025.PetStatus) error {
+	if status.Initialized {
+		pet.Status = *status.DeepCopy()
+	} else {
+		pet.Status = linuxfestv2025.PetStatus{}
+	}
+	if err := r.Status().Update(ctx, pet); err != nil {
+		return fmt.Errorf("restoring status, remove the %s annotation to let the controller take over: %w",
+			linuxfestv2025.RestoringAnnotation, err)
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var latest linuxfestv2025.Pet
+		if err := r.Get(ctx, client.ObjectKeyFromObject(pet), &latest); err != nil {
+			return err
+		}
+		cpy := latest.DeepCopy()
+		delete(cpy.Annotations, linuxfestv2025.RestoringAnnotation)
+		return r.Update(ctx, cpy)
+	})
+}
+
+// freeName returns the first unused "<name>-restored-N" name for saved.
+func (r *Restorer) freeName(ctx context.Context, saved *linuxfestv2025.Pet) (string, error) {
+	for i := 1; i <= maxRenames; i++ {
+		name := fmt.Sprintf("%s-restored-%d", saved.Name, i)
+
+		var pet linuxfestv2025.Pet
+		err := r.Get(ctx, client.ObjectKey{Namespace: saved.Namespace, Name: name}, &pet)
+		if apierrors.IsNotFound(err) {
+			return name, nil
+		} else if err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("no free name after %d attempts", maxRenames)
+}
+
+// restoring returns a copy of annotations that keeps the controller away.
+func restoring(annotations map[string]string) map[string]string {
+	cpy := maps.Clone(annotations)
+	if cpy == nil {
+		cpy = map[string]string{}
+	}
+	cpy[linuxfestv2025.RestoringAnnotation] = "true"
+	return cpy
+}
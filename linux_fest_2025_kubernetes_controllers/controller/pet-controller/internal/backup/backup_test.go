@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/config"
+)
+
+var _ = Describe("Backup", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+		saved  *linuxfestv2025.Pet
+	)
+
+	newClient := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&linuxfestv2025.Pet{}).
+			Build()
+	}
+
+	archiveOf := func(pets ...linuxfestv2025.Pet) *Archive {
+		return &Archive{APIVersion: APIVersion, Kind: Kind, Pets: pets}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme = runtime.NewScheme()
+		Expect(linuxfestv2025.AddToScheme(scheme)).To(Succeed())
+
+		saved = &linuxfestv2025.Pet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "default",
+				Name:            "rex",
+				UID:             "rex-uid",
+				ResourceVersion: "42",
+				Labels:          map[string]string{"team": "sre"},
+			},
+			Spec: linuxfestv2025.PetSpec{Nickname: "Rex"},
+			Status: linuxfestv2025.PetStatus{
+				Initialized: true,
+				Food:        12,
+				Love:        34,
+				Health:      56,
+				Stage:       linuxfestv2025.PetStageAdult,
+			},
+		}
+	})
+
+	It("should export pets with their status but without cluster metadata", func() {
+		archive, err := Export(ctx, newClient(saved), config.Default().Annotations, client.InNamespace("default"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(archive.Pets).To(HaveLen(1))
+
+		pet := archive.Pets[0]
+		Expect(pet.Status).To(Equal(saved.Status))
+		Expect(pet.Labels).To(Equal(saved.Labels))
+		Expect(pet.UID).To(BeEmpty())
+		Expect(pet.ResourceVersion).To(BeEmpty())
+	})
+
+	It("should leave out pending actions", func() {
+		actions := config.AnnotationConfig{Feed: "example.com/feed", Pet: "example.com/pet", Revive: "example.com/revive"}
+		saved.Annotations = map[string]string{
+			actions.Feed:                       "30",
+			actions.Pet:                        "30",
+			actions.Revive:                     "true",
+			linuxfestv2025.RequesterAnnotation: "alice",
+			"team":                             "sre",
+		}
+
+		archive, err := Export(ctx, newClient(saved), actions, client.InNamespace("default"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(archive.Pets).To(HaveExactElements(HaveField("Annotations", Equal(map[string]string{"team": "sre"}))))
+	})
+
+	It("should round trip archives and reject unknown versions", func() {
+		var buf bytes.Buffer
+		Expect(Encode(&buf, archiveOf(*saved))).To(Succeed())
+
+		archive, err := Decode(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(archive.Pets[0].Status.Food).To(Equal(12))
+
+		_, err = Decode(strings.NewReader("apiVersion: backup.linuxfest.example.com/v0\nkind: PetArchive\n"))
+		Expect(err).To(MatchError(ContainSubstring("unsupported archive")))
+	})
+
+	It("should restore the status and hand the pet back to the controller", func() {
+		c := newClient()
+		restorer := &Restorer{Client: c, Policy: ConflictSkip}
+
+		results, err := restorer.Restore(ctx, archiveOf(*saved))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(ConsistOf(HaveField("Action", ActionCreated)))
+
+		var pet linuxfestv2025.Pet
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(saved), &pet)).To(Succeed())
+		Expect(pet.Status).To(Equal(saved.Status))
+		Expect(pet.Annotations).NotTo(HaveKey(linuxfestv2025.RestoringAnnotation))
+	})
+
+	It("should leave uninitialized pets to the controller", func() {
+		saved.Status.Initialized = false
+		c := newClient()
+
+		_, err := (&Restorer{Client: c}).Restore(ctx, archiveOf(*saved))
+		Expect(err).NotTo(HaveOccurred())
+
+		var pet linuxfestv2025.Pet
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(saved), &pet)).To(Succeed())
+		Expect(pet.Status).To(Equal(linuxfestv2025.PetStatus{}))
+	})
+
+	Context("when the pet already exists", func() {
+		var (
+			c        client.Client
+			existing *linuxfestv2025.Pet
+		)
+
+		BeforeEach(func() {
+			existing = &linuxfestv2025.Pet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "rex"},
+				Spec:       linuxfestv2025.PetSpec{Nickname: "Other Rex"},
+				Status:     linuxfestv2025.PetStatus{Initialized: true, Food: 100, Love: 100},
+			}
+			c = newClient(existing)
+		})
+
+		get := func(name string) *linuxfestv2025.Pet {
+			var pet linuxfestv2025.Pet
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &pet)).To(Succeed())
+			return &pet
+		}
+
+		It("should skip it", func() {
+			results, err := (&Restorer{Client: c, Policy: ConflictSkip}).Restore(ctx, archiveOf(*saved))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(ConsistOf(HaveField("Action", ActionSkipped)))
+			Expect(get("rex").Spec.Nickname).To(Equal("Other Rex"))
+		})
+
+		It("should overwrite it", func() {
+			results, err := (&Restorer{Client: c, Policy: ConflictOverwrite}).Restore(ctx, archiveOf(*saved))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(ConsistOf(HaveField("Action", ActionOverwritten)))
+
+			pet := get("rex")
+			Expect(pet.Spec.Nickname).To(Equal("Rex"))
+			Expect(pet.Status).To(Equal(saved.Status))
+		})
+
+		It("should restore it under a free name", func() {
+			taken := existing.DeepCopy()
+			taken.Name = "rex-restored-1"
+			taken.ResourceVersion = ""
+			Expect(c.Create(ctx, taken)).To(Succeed())
+
+			results, err := (&Restorer{Client: c, Policy: ConflictRename}).Restore(ctx, archiveOf(*saved))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(ConsistOf(And(
+				HaveField("Action", ActionRenamed),
+				HaveField("Name", "rex-restored-2"),
+			)))
+			Expect(get("rex-restored-2").Status).To(Equal(saved.Status))
+			Expect(get("rex").Spec.Nickname).To(Equal("Other Rex"))
+		})
+
+		It("should not change anything in dry run mode", func() {
+			restorer := &Restorer{Client: c, Policy: ConflictOverwrite, DryRun: true}
+			results, err := restorer.Restore(ctx, archiveOf(*saved))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(ConsistOf(HaveField("Action", ActionOverwritten)))
+
+			pet := get("rex")
+			Expect(pet.Spec.Nickname).To(Equal("Other Rex"))
+			Expect(pet.Annotations).NotTo(HaveKey(linuxfestv2025.RestoringAnnotation))
+		})
+	})
+})
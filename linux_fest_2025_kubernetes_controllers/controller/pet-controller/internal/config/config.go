@@ -0,0 +1,554 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config loads the versioned pet controller configuration file and
+// keeps it up to date while the manager runs.
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	"github.com/itzloop/pet-controller/pkg/engine"
+)
+
+const (
+	// APIVersion is the only supported configuration apiVersion.
+	APIVersion = "config.linuxfest.example.com/v1alpha1"
+
+	// Kind is the kind of the configuration file.
+	Kind = "PetControllerConfiguration"
+
+	// maxStat is the largest food, love or health value the Pet CRD accepts.
+	maxStat = 100
+)
+
+// Configuration is the pet controller configuration file.
+type Configuration struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	// Pet holds the gameplay tunables.
+	Pet PetConfig `json:"pet"`
+
+	// Health holds the tunables of pet health and sickness.
+	Health HealthConfig `json:"health"`
+
+	// Stages holds the tunables of the life stages.
+	Stages StagesConfig `json:"stages"`
+
+	// Social holds the tunables of friendships between pets.
+	Social SocialConfig `json:"social"`
+
+	// Revival holds the tunables of reviving dead pets.
+	Revival RevivalConfig `json:"revival"`
+
+	// History configures the per pet history ConfigMaps.
+	History HistoryConfig `json:"history"`
+
+	// RateLimit throttles feed and pet requests.
+	RateLimit RateLimitConfig `json:"rateLimit"`
+
+	// Overfeeding holds the consequences of feeding pets beyond a cap.
+	Overfeeding OverfeedingConfig `json:"overfeeding"`
+
+	// Species are the species profiles pets select with spec.species.
+	Species map[string]SpeciesConfig `json:"species,omitempty"`
+
+	// Annotations are the annotation keys clients use to act on pets.
+	Annotations AnnotationConfig `json:"annotations"`
+}
+
+// PetConfig holds the gameplay tunables.
+type PetConfig struct {
+	// InitialFood is the food a pet starts with.
+	InitialFood int `json:"initialFood"`
+
+	// InitialLove is the love a pet starts with.
+	InitialLove int `json:"initialLove"`
+
+	// MaxFood caps the food a pet can have.
+	MaxFood int `json:"maxFood"`
+
+	// MaxLove caps the love a pet can have.
+	MaxLove int `json:"maxLove"`
+
+	// HungryThreshold is the food level below which a pet is hungry.
+	HungryThreshold int `json:"hungryThreshold"`
+}
+
+// HealthConfig holds the tunables of pet health and sickness.
+type HealthConfig struct {
+	// InitialHealth is the health a pet starts with.
+	InitialHealth int `json:"initialHealth"`
+
+	// FoodThreshold is the food level below which a pet is neglected.
+	FoodThreshold int `json:"foodThreshold"`
+
+	// LoveThreshold is the love level below which a pet is neglected.
+	LoveThreshold int `json:"loveThreshold"`
+
+	// NeglectIntervals is the number of consecutive neglected decay intervals
+	// after which a pet starts losing health.
+	NeglectIntervals int `json:"neglectIntervals"`
+
+	// DecayRate is the health a neglected pet loses every decay interval.
+	DecayRate int `json:"decayRate"`
+
+	// RecoveryRate is the health a cared for pet regains every decay interval.
+	RecoveryRate int `json:"recoveryRate"`
+
+	// SickThreshold is the health below which a pet is sick.
+	SickThreshold int `json:"sickThreshold"`
+}
+
+// StagesConfig holds the tunables of the life stages.
+type StagesConfig struct {
+	// AdultAfter is the lifetime after which a baby grows up.
+	AdultAfter metav1.Duration `json:"adultAfter"`
+
+	// AdultMinCare is the care score, between 0 and 100, a baby needs to grow up.
+	AdultMinCare int `json:"adultMinCare"`
+
+	// SeniorAfter is the lifetime after which an adult with a care score of 50
+	// becomes a senior. Better cared for pets age later, neglected pets sooner.
+	SeniorAfter metav1.Duration `json:"seniorAfter"`
+
+	// Baby, Adult and Senior tune the pets in each stage. By default every stage
+	// plays by the same rules, so stages only change how pets age.
+	Baby   StageConfig `json:"baby"`
+	Adult  StageConfig `json:"adult"`
+	Senior StageConfig `json:"senior"`
+}
+
+// StageConfig holds the tunables of a single life stage.
+type StageConfig struct {
+	// DecayMultiplier scales the pet's food and love decay rates.
+	DecayMultiplier float64 `json:"decayMultiplier"`
+
+	// MaxFood caps the food of pets in this stage.
+	MaxFood int `json:"maxFood"`
+
+	// MaxLove caps the love of pets in this stage.
+	MaxLove int `json:"maxLove"`
+}
+
+// SocialConfig holds the tunables of friendships between pets.
+type SocialConfig struct {
+	// FriendshipThreshold is the love both friends need for their friendship to grow.
+	FriendshipThreshold int `json:"friendshipThreshold"`
+
+	// FriendshipBonus is the love a pet gains every decay interval from each happy friend.
+	FriendshipBonus int `json:"friendshipBonus"`
+
+	// GriefPenalty is the love a pet loses when one of its friends dies.
+	GriefPenalty int `json:"griefPenalty"`
+}
+
+// RevivalConfig holds the tunables of reviving dead pets.
+type RevivalConfig struct {
+	// Cost is the stock taken from the pet's household for every revival.
+	Cost int `json:"cost"`
+
+	// Cooldown is the minimum time between two revivals of the same pet.
+	Cooldown metav1.Duration `json:"cooldown"`
+}
+
+// HistoryConfig configures the per pet history ConfigMaps.
+type HistoryConfig struct {
+	// Enabled turns on recording a sample for every change of a pet.
+	Enabled bool `json:"enabled"`
+
+	// Size is the number of samples kept per pet, older samples are dropped.
+	Size int `json:"size"`
+}
+
+// RateLimitConfig throttles feed and pet requests with token buckets.
+type RateLimitConfig struct {
+	// Pet is the bucket of every pet.
+	Pet BucketConfig `json:"pet"`
+
+	// User is the bucket of every caretaker in a namespace. With sharding every
+	// replica keeps its own bucket for a caretaker.
+	User BucketConfig `json:"user"`
+
+	// Namespaces override the buckets of pets and caretakers in single namespaces.
+	Namespaces map[string]RateLimitOverride `json:"namespaces,omitempty"`
+}
+
+// RateLimitOverride replaces the buckets of a namespace.
+type RateLimitOverride struct {
+	Pet  *BucketConfig `json:"pet,omitempty"`
+	User *BucketConfig `json:"user,omitempty"`
+}
+
+// BucketConfig is a token bucket.
+type BucketConfig struct {
+	// Interval is the time it takes to refill one token. Zero disables the limit.
+	Interval metav1.Duration `json:"interval"`
+
+	// Burst is the number of tokens the bucket holds.
+	Burst int `json:"burst"`
+}
+
+// OverfeedingConfig holds the consequences of feeding pets beyond a cap.
+type OverfeedingConfig struct {
+	// Cap is the food level feeding may fill a pet up to. Feeding beyond it, or
+	// beyond the pet's max food, overfeeds the pet.
+	Cap int `json:"cap"`
+
+	// Duration is how long a pet stays overfed.
+	Duration metav1.Duration `json:"duration"`
+
+	// LoveGainMultiplier scales the love an overfed pet gains from petting.
+	LoveGainMultiplier float64 `json:"loveGainMultiplier"`
+
+	// DecayMultiplier scales the food decay rate of an overfed pet.
+	DecayMultiplier float64 `json:"decayMultiplier"`
+
+	// HealthPenalty is the health a pet loses when it is overfed while still overfed.
+	HealthPenalty int `json:"healthPenalty"`
+}
+
+// SpeciesConfig is the profile shared by all pets of a species.
+type SpeciesConfig struct {
+	// RateLimit replaces the pet bucket of pets of this species.
+	RateLimit *BucketConfig `json:"rateLimit,omitempty"`
+
+	// Overfeeding replaces the overfeeding rules of pets of this species.
+	Overfeeding *OverfeedingConfig `json:"overfeeding,omitempty"`
+}
+
+// OverfeedingRules returns the overfeeding rules of pets of species.
+func (c *Configuration) OverfeedingRules(species string) OverfeedingConfig {
+	if profile, ok := c.Species[species]; ok && profile.Overfeeding != nil {
+		return *profile.Overfeeding
+	}
+	return c.Overfeeding
+}
+
+// Rules returns the game rules pets of species play by.
+func (c *Configuration) Rules(species string) engine.Rules {
+	overfeeding := c.OverfeedingRules(species)
+	stage := func(s StageConfig) engine.StageRule {
+		return engine.StageRule{DecayMultiplier: s.DecayMultiplier, MaxFood: s.MaxFood, MaxLove: s.MaxLove}
+	}
+
+	return engine.Rules{
+		InitialFood:     c.Pet.InitialFood,
+		InitialLove:     c.Pet.InitialLove,
+		MaxFood:         c.Pet.MaxFood,
+		MaxLove:         c.Pet.MaxLove,
+		HungryThreshold: c.Pet.HungryThreshold,
+		Health: engine.HealthRules{
+			Initial:          c.Health.InitialHealth,
+			FoodThreshold:    c.Health.FoodThreshold,
+			LoveThreshold:    c.Health.LoveThreshold,
+			NeglectIntervals: c.Health.NeglectIntervals,
+			DecayRate:        c.Health.DecayRate,
+			RecoveryRate:     c.Health.RecoveryRate,
+			SickThreshold:    c.Health.SickThreshold,
+		},
+		Stages: engine.StageRules{
+			AdultAfter:   c.Stages.AdultAfter.Duration,
+			AdultMinCare: c.Stages.AdultMinCare,
+			SeniorAfter:  c.Stages.SeniorAfter.Duration,
+			Baby:         stage(c.Stages.Baby),
+			Adult:        stage(c.Stages.Adult),
+			Senior:       stage(c.Stages.Senior),
+		},
+		Social: engine.SocialRules{
+			FriendshipThreshold: c.Social.FriendshipThreshold,
+			FriendshipBonus:     c.Social.FriendshipBonus,
+			GriefPenalty:        c.Social.GriefPenalty,
+		},
+		Overfeeding: engine.OverfeedingRules{
+			Cap:                overfeeding.Cap,
+			Duration:           overfeeding.Duration.Duration,
+			LoveGainMultiplier: overfeeding.LoveGainMultiplier,
+			DecayMultiplier:    overfeeding.DecayMultiplier,
+			HealthPenalty:      overfeeding.HealthPenalty,
+		},
+	}
+}
+
+// PetBucket returns the bucket of a pet of species in namespace. Species
+// profiles take precedence over namespace overrides.
+func (c *Configuration) PetBucket(namespace, species string) BucketConfig {
+	if profile, ok := c.Species[species]; ok && profile.RateLimit != nil {
+		return *profile.RateLimit
+	}
+	if override, ok := c.RateLimit.Namespaces[namespace]; ok && override.Pet != nil {
+		return *override.Pet
+	}
+	return c.RateLimit.Pet
+}
+
+// UserBucket returns the bucket of a caretaker in namespace.
+func (c *Configuration) UserBucket(namespace string) BucketConfig {
+	if override, ok := c.RateLimit.Namespaces[namespace]; ok && override.User != nil {
+		return *override.User
+	}
+	return c.RateLimit.User
+}
+
+// AnnotationConfig holds the annotation keys clients use to act on pets.
+type AnnotationConfig struct {
+	// Feed is the annotation holding the food to give a pet.
+	Feed string `json:"feed"`
+
+	// Pet is the annotation holding the love to give a pet.
+	Pet string `json:"pet"`
+
+	// Revive is the annotation requesting a dead pet to be revived.
+	Revive string `json:"revive"`
+}
+
+// Default returns the configuration used when no file is given.
+func Default() *Configuration {
+	return &Configuration{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		Pet: PetConfig{
+			InitialFood:     100,
+			InitialLove:     100,
+			MaxFood:         100,
+			MaxLove:         100,
+			HungryThreshold: 30,
+		},
+		Health: HealthConfig{
+			InitialHealth:    100,
+			FoodThreshold:    30,
+			LoveThreshold:    30,
+			NeglectIntervals: 3,
+			DecayRate:        10,
+			RecoveryRate:     5,
+			SickThreshold:    50,
+		},
+		Stages: StagesConfig{
+			AdultAfter:   metav1.Duration{Duration: 10 * time.Minute},
+			AdultMinCare: 50,
+			SeniorAfter:  metav1.Duration{Duration: 24 * time.Hour},
+			Baby:         StageConfig{DecayMultiplier: 1, MaxFood: 100, MaxLove: 100},
+			Adult:        StageConfig{DecayMultiplier: 1, MaxFood: 100, MaxLove: 100},
+			Senior:       StageConfig{DecayMultiplier: 1, MaxFood: 100, MaxLove: 100},
+		},
+		Social: SocialConfig{
+			FriendshipThreshold: 50,
+			FriendshipBonus:     2,
+			GriefPenalty:        20,
+		},
+		Revival: RevivalConfig{
+			Cost:     10,
+			Cooldown: metav1.Duration{Duration: time.Hour},
+		},
+		History: HistoryConfig{
+			Size: 288,
+		},
+		RateLimit: RateLimitConfig{
+			Pet:  BucketConfig{Interval: metav1.Duration{Duration: 10 * time.Second}, Burst: 5},
+			User: BucketConfig{Interval: metav1.Duration{Duration: 2 * time.Second}, Burst: 10},
+		},
+		Overfeeding: OverfeedingConfig{
+			Cap:                100,
+			Duration:           metav1.Duration{Duration: 5 * time.Minute},
+			LoveGainMultiplier: 0.5,
+			DecayMultiplier:    2,
+			HealthPenalty:      10,
+		},
+		Annotations: AnnotationConfig{
+			Feed:   "linuxfest.example.com/feed",
+			Pet:    "linuxfest.example.com/pet",
+			Revive: "linuxfest.example.com/revive",
+		},
+	}
+}
+
+// Load reads and validates the configuration file at path. Fields missing from
+// the file keep their default values.
+func Load(path string) (*Configuration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// apiVersion and kind must come from the file, which also rejects a file
+	// that is empty because it is being rewritten.
+	cfg := Default()
+	cfg.APIVersion, cfg.Kind = "", ""
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("validating %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate reports every invalid field of the configuration.
+func (c *Configuration) Validate() error {
+	var errs field.ErrorList
+
+	if c.APIVersion != APIVersion {
+		errs = append(errs, field.NotSupported(field.NewPath("apiVersion"), c.APIVersion, []string{APIVersion}))
+	}
+	if c.Kind != Kind {
+		errs = append(errs, field.NotSupported(field.NewPath("kind"), c.Kind, []string{Kind}))
+	}
+
+	pet := field.NewPath("pet")
+	errs = append(errs, validateRange(pet.Child("maxFood"), c.Pet.MaxFood, 1, maxStat)...)
+	errs = append(errs, validateRange(pet.Child("maxLove"), c.Pet.MaxLove, 1, maxStat)...)
+	errs = append(errs, validateRange(pet.Child("initialFood"), c.Pet.InitialFood, 1, c.Pet.MaxFood)...)
+	errs = append(errs, validateRange(pet.Child("initialLove"), c.Pet.InitialLove, 1, c.Pet.MaxLove)...)
+	errs = append(errs, validateRange(pet.Child("hungryThreshold"), c.Pet.HungryThreshold, 0, c.Pet.MaxFood)...)
+
+	health := field.NewPath("health")
+	errs = append(errs, validateRange(health.Child("initialHealth"), c.Health.InitialHealth, 1, maxStat)...)
+	errs = append(errs, validateRange(health.Child("foodThreshold"), c.Health.FoodThreshold, 0, c.Pet.MaxFood)...)
+	errs = append(errs, validateRange(health.Child("loveThreshold"), c.Health.LoveThreshold, 0, c.Pet.MaxLove)...)
+	if c.Health.NeglectIntervals < 1 {
+		errs = append(errs, field.Invalid(health.Child("neglectIntervals"), c.Health.NeglectIntervals, "must be positive"))
+	}
+	errs = append(errs, validateRange(health.Child("decayRate"), c.Health.DecayRate, 1, maxStat)...)
+	errs = append(errs, validateRange(health.Child("recoveryRate"), c.Health.RecoveryRate, 0, maxStat)...)
+	errs = append(errs, validateRange(health.Child("sickThreshold"), c.Health.SickThreshold, 0, maxStat)...)
+
+	stages := field.NewPath("stages")
+	if c.Stages.AdultAfter.Duration < 0 {
+		errs = append(errs, field.Invalid(stages.Child("adultAfter"), c.Stages.AdultAfter.Duration.String(), "must not be negative"))
+	}
+	if c.Stages.SeniorAfter.Duration <= c.Stages.AdultAfter.Duration {
+		errs = append(errs, field.Invalid(stages.Child("seniorAfter"), c.Stages.SeniorAfter.Duration.String(), "must be longer than adultAfter"))
+	}
+	errs = append(errs, validateRange(stages.Child("adultMinCare"), c.Stages.AdultMinCare, 0, 100)...)
+	errs = append(errs, c.Stages.Baby.validate(stages.Child("baby"), c.Pet)...)
+	errs = append(errs, c.Stages.Adult.validate(stages.Child("adult"), c.Pet)...)
+	errs = append(errs, c.Stages.Senior.validate(stages.Child("senior"), c.Pet)...)
+
+	social := field.NewPath("social")
+	errs = append(errs, validateRange(social.Child("friendshipThreshold"), c.Social.FriendshipThreshold, 0, c.Pet.MaxLove)...)
+	errs = append(errs, validateRange(social.Child("friendshipBonus"), c.Social.FriendshipBonus, 0, c.Pet.MaxLove)...)
+	errs = append(errs, validateRange(social.Child("griefPenalty"), c.Social.GriefPenalty, 0, c.Pet.MaxLove)...)
+
+	revival := field.NewPath("revival")
+	if c.Revival.Cost < 0 {
+		errs = append(errs, field.Invalid(revival.Child("cost"), c.Revival.Cost, "must not be negative"))
+	}
+	if c.Revival.Cooldown.Duration < 0 {
+		errs = append(errs, field.Invalid(revival.Child("cooldown"), c.Revival.Cooldown.Duration.String(), "must not be negative"))
+	}
+
+	// 📦 ConfigMaps are limited to 1MiB, a sample takes roughly 100 bytes
+	errs = append(errs, validateRange(field.NewPath("history", "size"), c.History.Size, 1, 5000)...)
+
+	rateLimit := field.NewPath("rateLimit")
+	errs = append(errs, c.RateLimit.Pet.validate(rateLimit.Child("pet"))...)
+	errs = append(errs, c.RateLimit.User.validate(rateLimit.Child("user"))...)
+	for namespace, override := range c.RateLimit.Namespaces {
+		path := rateLimit.Child("namespaces").Key(namespace)
+		if override.Pet != nil {
+			errs = append(errs, override.Pet.validate(path.Child("pet"))...)
+		}
+		if override.User != nil {
+			errs = append(errs, override.User.validate(path.Child("user"))...)
+		}
+	}
+
+	errs = append(errs, c.Overfeeding.validate(field.NewPath("overfeeding"))...)
+
+	for name, profile := range c.Species {
+		path := field.NewPath("species").Key(name)
+		if name == "" {
+			errs = append(errs, field.Invalid(path, name, "must not be empty"))
+		}
+		if profile.RateLimit != nil {
+			errs = append(errs, profile.RateLimit.validate(path.Child("rateLimit"))...)
+		}
+		if profile.Overfeeding != nil {
+			errs = append(errs, profile.Overfeeding.validate(path.Child("overfeeding"))...)
+		}
+	}
+
+	annotations := field.NewPath("annotations")
+	seen := map[string]bool{}
+	for _, annotation := range []struct {
+		name, key string
+	}{
+		{"feed", c.Annotations.Feed},
+		{"pet", c.Annotations.Pet},
+		{"revive", c.Annotations.Revive},
+	} {
+		switch {
+		case annotation.key == "":
+			errs = append(errs, field.Required(annotations.Child(annotation.name), ""))
+		case seen[annotation.key]:
+			errs = append(errs, field.Duplicate(annotations.Child(annotation.name), annotation.key))
+		}
+		seen[annotation.key] = true
+	}
+
+	return errs.ToAggregate()
+}
+
+func (s StageConfig) validate(path *field.Path, pet PetConfig) field.ErrorList {
+	var errs field.ErrorList
+	if s.DecayMultiplier <= 0 {
+		errs = append(errs, field.Invalid(path.Child("decayMultiplier"), s.DecayMultiplier, "must be positive"))
+	}
+	errs = append(errs, validateRange(path.Child("maxFood"), s.MaxFood, 1, pet.MaxFood)...)
+	errs = append(errs, validateRange(path.Child("maxLove"), s.MaxLove, 1, pet.MaxLove)...)
+	return errs
+}
+
+func (b BucketConfig) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if b.Interval.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("interval"), b.Interval.Duration.String(), "must not be negative"))
+	}
+	if b.Burst < 1 {
+		errs = append(errs, field.Invalid(path.Child("burst"), b.Burst, "must be positive"))
+	}
+	return errs
+}
+
+func (o OverfeedingConfig) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateRange(path.Child("cap"), o.Cap, 1, maxStat)...)
+	if o.Duration.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("duration"), o.Duration.Duration.String(), "must not be negative"))
+	}
+	if o.LoveGainMultiplier < 0 {
+		errs = append(errs, field.Invalid(path.Child("loveGainMultiplier"), o.LoveGainMultiplier, "must not be negative"))
+	}
+	if o.DecayMultiplier <= 0 {
+		errs = append(errs, field.Invalid(path.Child("decayMultiplier"), o.DecayMultiplier, "must be positive"))
+	}
+	errs = append(errs, validateRange(path.Child("healthPenalty"), o.HealthPenalty, 0, maxStat)...)
+	return errs
+}
+
+func validateRange(path *field.Path, value, lo, hi int) field.ErrorList {
+	if value < lo || value > hi {
+		return field.ErrorList{field.Invalid(path, value, fmt.Sprintf("must be between %d and %d", lo, hi))}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/itzloop/pet-controller/internal/config"
+	"github.com/itzloop/pet-controller/pkg/engine"
+)
+
+const header = `apiVersion: config.linuxfest.example.com/v1alpha1
+kind: PetControllerConfiguration
+`
+
+func writeConfig(path, body string) {
+	GinkgoHelper()
+	Expect(os.WriteFile(path, []byte(header+body), 0o600)).To(Succeed())
+}
+
+var _ = Describe("Load", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "config.yaml")
+	})
+
+	It("should keep defaults for missing fields", func() {
+		writeConfig(path, "pet:\n  hungryThreshold: 40\n")
+
+		cfg, err := config.Load(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Pet.HungryThreshold).To(Equal(40))
+		Expect(cfg.Pet.MaxFood).To(Equal(config.Default().Pet.MaxFood))
+		Expect(cfg.Annotations).To(Equal(config.Default().Annotations))
+	})
+
+	It("should play every stage by the pet rules by default", func() {
+		rules := config.Default().Rules("")
+		for _, stage := range []engine.Stage{engine.Baby, engine.Adult, engine.Senior} {
+			food, love := rules.Capacity(stage)
+			Expect(food).To(Equal(rules.MaxFood), "food of %s", stage)
+			Expect(love).To(Equal(rules.MaxLove), "love of %s", stage)
+
+			pet := engine.Pet{FoodDecayRate: 3, LoveDecayRate: 2, State: engine.State{Stage: stage}}
+			foodRate, loveRate := engine.DecayRates(pet, rules, time.Now())
+			Expect([]int{foodRate, loveRate}).To(Equal([]int{3, 2}), "decay rates of %s", stage)
+		}
+	})
+
+	It("should reject unknown fields", func() {
+		writeConfig(path, "pet:\n  hungryTreshold: 40\n")
+
+		_, err := config.Load(path)
+		Expect(err).To(MatchError(ContainSubstring("hungryTreshold")))
+	})
+
+	It("should reject unsupported versions", func() {
+		Expect(os.WriteFile(path, []byte("apiVersion: v2\nkind: PetControllerConfiguration\n"), 0o600)).To(Succeed())
+
+		_, err := config.Load(path)
+		Expect(err).To(MatchError(ContainSubstring("apiVersion")))
+	})
+
+	It("should report every invalid field", func() {
+		writeConfig(path, "pet:\n  maxFood: 150\n  initialLove: 0\nannotations:\n  feed: \"\"\n")
+
+		_, err := config.Load(path)
+		Expect(err).To(MatchError(ContainSubstring("pet.maxFood")))
+		Expect(err).To(MatchError(ContainSubstring("pet.initialLove")))
+		Expect(err).To(MatchError(ContainSubstring("annotations.feed")))
+	})
+
+	It("should reject duplicate annotation keys", func() {
+		writeConfig(path, "annotations:\n  revive: linuxfest.example.com/feed\n")
+
+		_, err := config.Load(path)
+		Expect(err).To(MatchError(ContainSubstring("annotations.revive")))
+	})
+
+	It("should parse stage durations and validate stages", func() {
+		writeConfig(path, "stages:\n  adultAfter: 2h\n  seniorAfter: 1h\n  baby:\n    decayMultiplier: 0\n    maxFood: 80\n    maxLove: 100\n")
+
+		_, err := config.Load(path)
+		Expect(err).To(MatchError(ContainSubstring("stages.seniorAfter")))
+		Expect(err).To(MatchError(ContainSubstring("stages.baby.decayMultiplier")))
+	})
+
+	It("should resolve rate limits by species, then namespace", func() {
+		writeConfig(path, `rateLimit:
+  namespaces:
+    playground:
+      pet:
+        interval: 1s
+        burst: 20
+species:
+  hamster:
+    rateLimit:
+      interval: 30s
+      burst: 2
+`)
+
+		cfg, err := config.Load(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.PetBucket("default", "")).To(Equal(config.Default().RateLimit.Pet))
+		Expect(cfg.PetBucket("playground", "").Burst).To(Equal(20))
+		Expect(cfg.PetBucket("playground", "hamster").Burst).To(Equal(2))
+		Expect(cfg.UserBucket("playground")).To(Equal(config.Default().RateLimit.User))
+	})
+
+	It("should use the overfeeding rules of the species profile", func() {
+		writeConfig(path, "species:\n  hamster:\n    overfeeding:\n      cap: 60\n      duration: 15m\n      loveGainMultiplier: 0.25\n      decayMultiplier: 0\n      healthPenalty: 20\n")
+
+		_, err := config.Load(path)
+		Expect(err).To(MatchError(ContainSubstring("species[hamster].overfeeding.decayMultiplier")))
+
+		writeConfig(path, "species:\n  hamster:\n    overfeeding:\n      cap: 60\n      duration: 15m\n      loveGainMultiplier: 0.25\n      decayMultiplier: 3\n      healthPenalty: 20\n")
+
+		cfg, err := config.Load(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.OverfeedingRules("hamster").Cap).To(Equal(60))
+		Expect(cfg.OverfeedingRules("cat")).To(Equal(config.Default().Overfeeding))
+	})
+
+	It("should validate rate limit buckets", func() {
+		writeConfig(path, "rateLimit:\n  namespaces:\n    playground:\n      user:\n        interval: 1s\n        burst: 0\n")
+
+		_, err := config.Load(path)
+		Expect(err).To(MatchError(ContainSubstring("rateLimit.namespaces[playground].user.burst")))
+	})
+})
+
+var _ = Describe("Store", func() {
+	It("should use the defaults without a file", func() {
+		store, err := config.NewStore("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.Get()).To(Equal(config.Default()))
+	})
+
+	It("should fail on an invalid file at startup", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		writeConfig(path, "pet:\n  maxLove: -1\n")
+
+		_, err := config.NewStore(path)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reload the file when it changes and ignore invalid updates", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		writeConfig(path, "pet:\n  hungryThreshold: 30\n")
+
+		store, err := config.NewStore(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		go func() { _ = store.Start(ctx) }()
+
+		hungryThreshold := func() int { return store.Get().Pet.HungryThreshold }
+
+		By("applying a valid change")
+		Eventually(func() int {
+			writeConfig(path, "pet:\n  hungryThreshold: 50\n")
+			return hungryThreshold()
+		}).Should(Equal(50))
+
+		By("keeping the last valid configuration")
+		writeConfig(path, "pet:\n  hungryThreshold: 500\n")
+		Consistently(hungryThreshold).Should(Equal(50))
+	})
+})
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Store holds the current configuration and reloads it whenever the file changes.
+// Invalid files are logged and ignored so a typo never takes down a running manager.
+type Store struct {
+	path    string
+	current atomic.Pointer[Configuration]
+}
+
+// NewStore loads the configuration file at path. An empty path uses the defaults.
+func NewStore(path string) (*Store, error) {
+	s := &Store{path: path}
+	if path == "" {
+		s.current.Store(Default())
+		return s, nil
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	s.current.Store(cfg)
+	return s, nil
+}
+
+// Get returns the current configuration. Callers must not modify it.
+func (s *Store) Get() *Configuration {
+	return s.current.Load()
+}
+
+// Start watches the configuration file until ctx is cancelled. The directory is
+// watched rather than the file so ConfigMap updates, which swap a symlink, are seen.
+func (s *Store) Start(ctx context.Context) error {
+	if s.path == "" {
+		return nil
+	}
+	log := log.FromContext(ctx).WithName("config").WithValues("path", s.path)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(filepath.Dir(s.path)); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-watcher.Errors:
+			log.Error(err, "error watching configuration")
+		case <-watcher.Events:
+			s.reload(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection allows every replica to follow configuration changes.
+func (s *Store) NeedLeaderElection() bool {
+	return false
+}
+
+func (s *Store) reload(ctx context.Context) {
+	log := log.FromContext(ctx).WithName("config").WithValues("path", s.path)
+
+	cfg, err := Load(s.path)
+	if err != nil {
+		log.Error(err, "ignoring invalid configuration")
+		return
+	}
+	if reflect.DeepEqual(cfg, s.Get()) {
+		return
+	}
+
+	s.current.Store(cfg)
+	log.Info("Reloaded configuration", "pet", cfg.Pet, "annotations", cfg.Annotations)
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// NewDryRunClient returns a client that reads through c but only logs the
+// writes it is asked to make, as JSON merge patches against the cached objects,
+// instead of sending them.
+func NewDryRunClient(c client.Client) client.Client {
+	return &dryRunClient{Client: c}
+}
+
+type dryRunClient struct {
+	client.Client
+}
+
+func (c *dryRunClient) Create(ctx context.Context, obj client.Object, _ ...client.CreateOption) error {
+	return c.record(ctx, "create", "", obj, nil)
+}
+
+func (c *dryRunClient) Update(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	return c.record(ctx, "update", "", obj, nil)
+}
+
+func (c *dryRunClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, _ ...client.PatchOption) error {
+	return c.record(ctx, "patch", "", obj, patch)
+}
+
+func (c *dryRunClient) Delete(ctx context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	return c.record(ctx, "delete", "", obj, nil)
+}
+
+func (c *dryRunClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	listOpts := (&client.DeleteAllOfOptions{}).ApplyOptions(opts).ListOptions
+	log.FromContext(ctx).Info("Dry run: skipping delete all", "kind", c.kind(obj),
+		"namespace", listOpts.Namespace, "selector", fmt.Sprint(listOpts.LabelSelector))
+	dryRunWrites.WithLabelValues("deleteAllOf", c.kind(obj), "").Inc()
+	return nil
+}
+
+func (c *dryRunClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *dryRunClient) SubResource(subResource string) client.SubResourceClient {
+	return &dryRunSubResourceClient{SubResourceClient: c.Client.SubResource(subResource), client: c, name: subResource}
+}
+
+// record logs the write verb of obj would make and counts it.
+func (c *dryRunClient) record(ctx context.Context, verb, subResource string, obj client.Object, patch client.Patch) error {
+	kind := c.kind(obj)
+	dryRunWrites.WithLabelValues(verb, kind, subResource).Inc()
+
+	values := []any{"verb", verb, "kind", kind, "namespace", obj.GetNamespace(), "name", obj.GetName()}
+	if subResource != "" {
+		values = append(values, "subresource", subResource)
+	}
+	if verb != "delete" {
+		diff, err := c.diff(ctx, obj, patch)
+		if err != nil {
+			return fmt.Errorf("computing dry run diff: %w", err)
+		}
+		values = append(values, "diff", string(diff))
+	}
+	log.FromContext(ctx).Info("Dry run: skipping write", values...)
+	return nil
+}
+
+// diff returns patch, or a JSON merge patch from the cached obj to obj.
+func (c *dryRunClient) diff(ctx context.Context, obj client.Object, patch client.Patch) ([]byte, error) {
+	if patch != nil {
+		return patch.Data(obj)
+	}
+
+	current, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a client.Object", obj)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		// 🆕 Everything is new
+		empty, err := c.Scheme().New(gvkOf(c.Scheme(), obj))
+		if err != nil {
+			return nil, err
+		}
+		current = empty.(client.Object)
+	}
+	return client.MergeFrom(current).Data(obj)
+}
+
+func (c *dryRunClient) kind(obj runtime.Object) string {
+	return gvkOf(c.Scheme(), obj).Kind
+}
+
+// gvkOf returns the group, version and kind of obj, or just its Go type when
+// the scheme doesn't know it.
+func gvkOf(scheme *runtime.Scheme, obj runtime.Object) schema.GroupVersionKind {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return schema.GroupVersionKind{Kind: fmt.Sprintf("%T", obj)}
+	}
+	return gvk
+}
+
+type dryRunSubResourceClient struct {
+	client.SubResourceClient
+	client *dryRunClient
+	name   string
+}
+
+func (c *dryRunSubResourceClient) Create(ctx context.Context, obj client.Object, _ client.Object, _ ...client.SubResourceCreateOption) error {
+	return c.client.record(ctx, "create", c.name, obj, nil)
+}
+
+func (c *dryRunSubResourceClient) Update(ctx context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+	return c.client.record(ctx, "update", c.name, obj, nil)
+}
+
+func (c *dryRunSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, _ ...client.SubResourcePatchOption) error {
+	return c.client.record(ctx, "patch", c.name, obj, patch)
+}
+
+// dryRunRecorder logs and counts events instead of recording them.
+type dryRunRecorder struct {
+	log logr.Logger
+}
+
+func newDryRunRecorder() record.EventRecorder {
+	return &dryRunRecorder{log: ctrl.Log.WithName("dry-run")}
+}
+
+func (r *dryRunRecorder) Event(object runtime.Object, eventType, reason, message string) {
+	r.AnnotatedEventf(object, nil, eventType, reason, "%s", message)
+}
+
+func (r *dryRunRecorder) Eventf(object runtime.Object, eventType, reason, messageFmt string, args ...any) {
+	r.AnnotatedEventf(object, nil, eventType, reason, messageFmt, args...)
+}
+
+func (r *dryRunRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventType, reason, messageFmt string, args ...any) {
+	dryRunEvents.WithLabelValues(eventType, reason).Inc()
+
+	values := []any{"type", eventType, "reason", reason, "message", fmt.Sprintf(messageFmt, args...)}
+	if obj, ok := object.(client.Object); ok {
+		values = append(values, "namespace", obj.GetNamespace(), "name", obj.GetName())
+	}
+	if len(annotations) > 0 {
+		values = append(values, "annotations", annotations)
+	}
+	r.log.Info("Dry run: skipping event", values...)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr/funcr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+)
+
+var _ = Describe("Dry run", func() {
+	var (
+		ctx      context.Context
+		logs     *strings.Builder
+		upstream client.Client
+		dryRun   client.Client
+		pet      *linuxfestv2025.Pet
+	)
+
+	BeforeEach(func() {
+		logs = &strings.Builder{}
+		ctx = log.IntoContext(context.Background(), funcr.New(func(prefix, args string) {
+			logs.WriteString(args + "\n")
+		}, funcr.Options{}))
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(linuxfestv2025.AddToScheme(scheme)).To(Succeed())
+
+		pet = &linuxfestv2025.Pet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "rex"},
+			Spec:       linuxfestv2025.PetSpec{Nickname: "Rex"},
+			Status:     linuxfestv2025.PetStatus{Food: 50, Love: 50},
+		}
+		upstream = fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(pet).WithStatusSubresource(pet).Build()
+		dryRun = NewDryRunClient(upstream)
+		Expect(upstream.Get(ctx, client.ObjectKeyFromObject(pet), pet)).To(Succeed())
+	})
+
+	It("should log status updates as diffs without making them", func() {
+		writes := dryRunWrites.WithLabelValues("update", "Pet", "status")
+		before := testutil.ToFloat64(writes)
+
+		cpy := pet.DeepCopy()
+		cpy.Status.Food = 40
+		Expect(dryRun.Status().Update(ctx, cpy)).To(Succeed())
+
+		var latest linuxfestv2025.Pet
+		Expect(upstream.Get(ctx, client.ObjectKeyFromObject(pet), &latest)).To(Succeed())
+		Expect(latest.Status.Food).To(Equal(50))
+		Expect(testutil.ToFloat64(writes)).To(Equal(before + 1))
+		Expect(logs.String()).To(ContainSubstring(`"verb"="update"`))
+		Expect(logs.String()).To(ContainSubstring(`"diff"="{\"status\":{\"food\":40}}"`))
+	})
+
+	It("should log patches without applying them", func() {
+		patch := client.MergeFrom(pet.DeepCopy())
+		pet.Labels = map[string]string{"team": "blue"}
+		Expect(dryRun.Patch(ctx, pet, patch)).To(Succeed())
+
+		var latest linuxfestv2025.Pet
+		Expect(upstream.Get(ctx, client.ObjectKeyFromObject(pet), &latest)).To(Succeed())
+		Expect(latest.Labels).To(BeEmpty())
+		Expect(logs.String()).To(ContainSubstring(`team`))
+	})
+
+	It("should not create or delete objects", func() {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "rex-history"},
+			Data:       map[string]string{"samples": "[]"},
+		}
+		Expect(dryRun.Create(ctx, cm)).To(Succeed())
+		Expect(upstream.Get(ctx, client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})).NotTo(Succeed())
+		Expect(logs.String()).To(ContainSubstring(`samples`))
+
+		Expect(dryRun.Delete(ctx, pet)).To(Succeed())
+		Expect(upstream.Get(ctx, client.ObjectKeyFromObject(pet), &linuxfestv2025.Pet{})).To(Succeed())
+		Expect(logs.String()).To(ContainSubstring(`"verb"="delete"`))
+	})
+
+	It("should count events instead of recording them", func() {
+		events := dryRunEvents.WithLabelValues(corev1.EventTypeWarning, "NeedFood")
+		before := testutil.ToFloat64(events)
+
+		newDryRunRecorder().Eventf(pet, corev1.EventTypeWarning, "NeedFood", "🍖 %s is hungry", "Rex")
+		Expect(testutil.ToFloat64(events)).To(Equal(before + 1))
+	})
+})
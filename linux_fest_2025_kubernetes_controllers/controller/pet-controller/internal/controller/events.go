@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/pkg/engine"
+)
+
+// Annotations attached to the events recorded for a pet.
+const (
+	annotationPreviousFood   = "linuxfest.example.com/previous-food"
+	annotationNewFood        = "linuxfest.example.com/new-food"
+	annotationPreviousLove   = "linuxfest.example.com/previous-love"
+	annotationNewLove        = "linuxfest.example.com/new-love"
+	annotationPreviousHealth = "linuxfest.example.com/previous-health"
+	annotationNewHealth      = "linuxfest.example.com/new-health"
+	annotationPreviousStage  = "linuxfest.example.com/previous-stage"
+	annotationNewStage       = "linuxfest.example.com/new-stage"
+)
+
+// recordEvents records the events the engine emitted for pet, annotated with
+// how its values changed from prev.
+func (r *PetReconciler) recordEvents(ctx context.Context, prev linuxfestv2025.PetStatus, pet *linuxfestv2025.Pet, events []engine.Event) {
+	for _, e := range events {
+		annotations := valueAnnotations(prev, pet.Status)
+		if e.Reason == "GrewUp" {
+			annotations[annotationPreviousStage] = string(prev.Stage)
+			annotations[annotationNewStage] = string(pet.Status.Stage)
+		}
+		r.event(ctx, pet, annotations, string(e.Type), e.Reason, e.Message)
+	}
+}
+
+// valueAnnotations returns the event annotations describing the change from prev to cur.
+func valueAnnotations(prev, cur linuxfestv2025.PetStatus) map[string]string {
+	return map[string]string{
+		annotationPreviousFood:   strconv.Itoa(prev.Food),
+		annotationNewFood:        strconv.Itoa(cur.Food),
+		annotationPreviousLove:   strconv.Itoa(prev.Love),
+		annotationNewLove:        strconv.Itoa(cur.Love),
+		annotationPreviousHealth: strconv.Itoa(prev.Health),
+		annotationNewHealth:      strconv.Itoa(cur.Health),
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/config"
+	"github.com/itzloop/pet-controller/pkg/engine"
+	"github.com/itzloop/pet-controller/pkg/engine/kube"
+)
+
+// treatmentPetIndex indexes treatments by the name of the pet they treat.
+const treatmentPetIndex = "spec.petName"
+
+// +kubebuilder:rbac:groups=linuxfest.example.com,resources=pettreatments,verbs=get;list;watch
+// +kubebuilder:rbac:groups=linuxfest.example.com,resources=pettreatments/status,verbs=get;update;patch
+
+// indexTreatmentPet is the indexer function for treatmentPetIndex.
+func indexTreatmentPet(obj client.Object) []string {
+	return []string{obj.(*linuxfestv2025.PetTreatment).Spec.PetName}
+}
+
+// treatedPet enqueues the pet a treatment is for.
+func treatedPet(_ context.Context, obj client.Object) []reconcile.Request {
+	treatment := obj.(*linuxfestv2025.PetTreatment)
+	if treatment.Status.Phase != "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: treatment.Namespace, Name: treatment.Spec.PetName}}}
+}
+
+// backfillHealth gives pets that were initialized before health existed their
+// initial health. Pets that already died stay dead: before health existed pets
+// died once they starved, so starved pets keep no health and are marked dead.
+func (r *PetReconciler) backfillHealth(ctx context.Context, pet *linuxfestv2025.Pet, cfg *config.Configuration) error {
+	if pet.Status.Health != 0 || isDead(pet) {
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(pet), pet); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		cpy := pet.DeepCopy()
+		now := r.now()
+		p := kube.Pet(cpy)
+		if !starved(cpy) {
+			p.Health = cfg.Health.InitialHealth
+		}
+		state, _ := engine.Evaluate(p, cfg.Rules(cpy.Spec.Species), now)
+		kube.Apply(cpy, state, now)
+		if err := r.Status().Update(ctx, cpy); err != nil {
+			return err
+		}
+
+		log.FromContext(ctx).Info("Backfilled health", "health", cpy.Status.Health)
+		*pet = *cpy
+		return nil
+	})
+}
+
+// starved reports whether pet died by the rules from before health existed.
+func starved(pet *linuxfestv2025.Pet) bool {
+	return pet.Status.Initialized && pet.Status.Health == 0 && pet.Status.Food == 0
+}
+
+// treat applies the pending treatments of pet. Dead pets can not be treated.
+func (r *PetReconciler) treat(ctx context.Context, pet *linuxfestv2025.Pet, cfg *config.Configuration) error {
+	var treatments linuxfestv2025.PetTreatmentList
+	if err := r.List(ctx, &treatments,
+		client.InNamespace(pet.Namespace),
+		client.MatchingFields{treatmentPetIndex: pet.Name},
+	); err != nil {
+		return err
+	}
+
+	for i := range treatments.Items {
+		treatment := &treatments.Items[i]
+		if treatment.Status.Phase != "" {
+			continue
+		}
+
+		phase, message := linuxfestv2025.PetTreatmentApplied, ""
+		if isDead(pet) {
+			phase, message = linuxfestv2025.PetTreatmentFailed, "dead pets can not be treated"
+		} else {
+			health, err := r.heal(ctx, pet, treatment, cfg)
+			if err != nil {
+				return err
+			}
+			message = fmt.Sprintf("health %d → %d", health, pet.Status.Health)
+		}
+
+		if err := r.completeTreatment(ctx, treatment, phase, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// heal adds the health restored by treatment to pet and returns the pet's previous health.
+func (r *PetReconciler) heal(ctx context.Context, pet *linuxfestv2025.Pet, treatment *linuxfestv2025.PetTreatment, cfg *config.Configuration) (int, error) {
+	var previous int
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(pet), pet); err != nil {
+			return err
+		}
+
+		cpy := pet.DeepCopy()
+		now := r.now()
+		state, events := engine.Heal(kube.Pet(cpy), cfg.Rules(cpy.Spec.Species), now, treatment.Spec.Health)
+		kube.Apply(cpy, state, now)
+		if err := r.Status().Update(ctx, cpy); err != nil {
+			return err
+		}
+
+		log.FromContext(ctx).Info("Treated pet", "treatment", treatment.Name, "health", cpy.Status.Health)
+		r.recordEvents(ctx, pet.Status, cpy, events)
+		r.recordHistory(ctx, cpy, "Treated", cfg)
+
+		previous = pet.Status.Health
+		*pet = *cpy
+		return nil
+	})
+	return previous, err
+}
+
+// completeTreatment records the outcome of treatment so it is not applied again.
+func (r *PetReconciler) completeTreatment(ctx context.Context, treatment *linuxfestv2025.PetTreatment, phase linuxfestv2025.PetTreatmentPhase, message string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(treatment), treatment); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		cpy := treatment.DeepCopy()
+		cpy.Status.Phase = phase
+		cpy.Status.AppliedTime = v1.NewTime(r.now())
+		cpy.Status.Message = message
+		return r.Status().Update(ctx, cpy)
+	})
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/config"
+)
+
+var _ = Describe("backfillHealth", func() {
+	ctx := context.Background()
+	cfg := config.Default()
+
+	// backfill backfills the health of a pet initialized before health existed.
+	backfill := func(status linuxfestv2025.PetStatus) *linuxfestv2025.Pet {
+		GinkgoHelper()
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(linuxfestv2025.AddToScheme(scheme)).To(Succeed())
+
+		pet := &linuxfestv2025.Pet{
+			ObjectMeta: metav1.ObjectMeta{Name: "rex", Namespace: "default"},
+			Spec:       linuxfestv2025.PetSpec{Nickname: "Rex"},
+			Status:     status,
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&linuxfestv2025.Pet{}).
+			WithObjects(pet).Build()
+		r := &PetReconciler{Client: c, Scheme: scheme, Clock: clocktesting.NewFakeClock(time.Now())}
+
+		Expect(r.backfillHealth(ctx, pet, cfg)).To(Succeed())
+
+		var stored linuxfestv2025.Pet
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(pet), &stored)).To(Succeed())
+		return &stored
+	}
+
+	It("should give living pets their initial health", func() {
+		pet := backfill(linuxfestv2025.PetStatus{Initialized: true, Food: 40, Love: 60})
+
+		Expect(pet.Status.Health).To(Equal(cfg.Health.InitialHealth))
+		Expect(isDead(pet)).To(BeFalse())
+	})
+
+	It("should keep pets that starved before health existed dead", func() {
+		pet := backfill(linuxfestv2025.PetStatus{Initialized: true, Food: 0, Love: 60})
+
+		Expect(pet.Status.Health).To(BeZero())
+		Expect(meta.IsStatusConditionTrue(pet.Status.Conditions, linuxfestv2025.PetConditionDead)).To(BeTrue())
+	})
+})
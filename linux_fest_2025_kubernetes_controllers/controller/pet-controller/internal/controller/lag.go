@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+)
+
+// lagWindow is how long the largest decay lag of a namespace is reported for.
+const lagWindow = time.Minute
+
+// decayLags records how late pets are decayed. Its zero value is ready to use.
+type decayLags struct {
+	mu         sync.Mutex
+	namespaces map[string]*windowMax
+}
+
+// windowMax is the largest lag seen since start.
+type windowMax struct {
+	start time.Time
+	max   time.Duration
+}
+
+// observe records that a pet of namespace with a decay interval was decayed
+// lag after it was due at now.
+func (l *decayLags) observe(now time.Time, namespace string, lag, interval time.Duration) {
+	lag = max(lag, 0)
+	decayLag.Observe(lag.Seconds())
+	if interval > 0 {
+		if missed := lag / interval; missed > 0 {
+			decayMissedIntervals.WithLabelValues(namespace).Add(float64(missed))
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.namespaces == nil {
+		l.namespaces = map[string]*windowMax{}
+	}
+	w, ok := l.namespaces[namespace]
+	if !ok || now.Sub(w.start) >= lagWindow {
+		w = &windowMax{start: now}
+		l.namespaces[namespace] = w
+	}
+	w.max = max(w.max, lag)
+	decayLagMax.WithLabelValues(namespace).Set(w.max.Seconds())
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("decayLags", func() {
+	now := time.Date(2025, 4, 26, 0, 0, 0, 0, time.UTC)
+
+	It("should report the largest lag of a namespace over the window", func() {
+		var lags decayLags
+		maxLag := decayLagMax.WithLabelValues("lag-window")
+
+		lags.observe(now, "lag-window", 2*time.Second, 10*time.Second)
+		lags.observe(now.Add(time.Second), "lag-window", time.Second, 10*time.Second)
+		Expect(testutil.ToFloat64(maxLag)).To(Equal(2.0))
+
+		lags.observe(now.Add(lagWindow), "lag-window", time.Second, 10*time.Second)
+		Expect(testutil.ToFloat64(maxLag)).To(Equal(1.0))
+	})
+
+	It("should count the whole intervals missed", func() {
+		var lags decayLags
+		missed := decayMissedIntervals.WithLabelValues("lag-missed")
+
+		lags.observe(now, "lag-missed", 9*time.Second, 10*time.Second)
+		Expect(testutil.ToFloat64(missed)).To(BeZero())
+
+		lags.observe(now, "lag-missed", 35*time.Second, 10*time.Second)
+		Expect(testutil.ToFloat64(missed)).To(Equal(3.0))
+	})
+
+	It("should not report negative lags", func() {
+		var lags decayLags
+
+		lags.observe(now, "lag-early", -time.Second, 10*time.Second)
+		Expect(testutil.ToFloat64(decayLagMax.WithLabelValues("lag-early"))).To(BeZero())
+	})
+})
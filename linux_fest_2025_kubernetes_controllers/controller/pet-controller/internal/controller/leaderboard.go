@@ -0,0 +1,478 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/sharding"
+)
+
+// Annotations attached to the events of an achievement.
+const (
+	annotationAchievement = "linuxfest.example.com/achievement"
+	annotationCaretaker   = "linuxfest.example.com/caretaker"
+)
+
+// achievement is a badge awarded once its goal is reached.
+type achievement struct {
+	name  string
+	title string
+}
+
+// caretakerAchievement is a badge awarded to a caretaker.
+type caretakerAchievement struct {
+	achievement
+	reached func(caretaker *linuxfestv2025.CaretakerStatus) bool
+}
+
+// petAchievement is a badge awarded to a pet.
+type petAchievement struct {
+	achievement
+	reached func(pet *linuxfestv2025.Pet, now time.Time) bool
+}
+
+var caretakerAchievements = []caretakerAchievement{
+	{
+		achievement: achievement{"Fed100", "Fed 100 times"},
+		reached:     func(c *linuxfestv2025.CaretakerStatus) bool { return c.Feedings >= 100 },
+	},
+	{
+		achievement: achievement{"Petted100", "Petted 100 times"},
+		reached:     func(c *linuxfestv2025.CaretakerStatus) bool { return c.Pettings >= 100 },
+	},
+}
+
+var petAchievements = []petAchievement{
+	{
+		achievement: achievement{"NoHunger7d", "7 days without hunger"},
+		reached: func(pet *linuxfestv2025.Pet, now time.Time) bool {
+			return conditionFalseFor(pet, linuxfestv2025.PetConditionHungry, now) >= 7*24*time.Hour
+		},
+	},
+	{
+		achievement: achievement{"Survivor30d", "Survived 30 days"},
+		reached: func(pet *linuxfestv2025.Pet, now time.Time) bool {
+			return conditionFalseFor(pet, linuxfestv2025.PetConditionDead, now) >= 30*24*time.Hour
+		},
+	},
+}
+
+// conditionFalseFor returns how long the condition of pet has been false.
+func conditionFalseFor(pet *linuxfestv2025.Pet, conditionType string, now time.Time) time.Duration {
+	cond := meta.FindStatusCondition(pet.Status.Conditions, conditionType)
+	if cond == nil || cond.Status != v1.ConditionFalse {
+		return 0
+	}
+	return now.Sub(cond.LastTransitionTime.Time)
+}
+
+// updateSurvival extends pet's longest survival streak with its current life
+// and awards the pet achievements it reached. It returns the new achievements.
+func updateSurvival(pet *linuxfestv2025.Pet, now time.Time) []achievement {
+	if alive := conditionFalseFor(pet, linuxfestv2025.PetConditionDead, now); alive > pet.Status.LongestSurvival.Duration {
+		pet.Status.LongestSurvival = v1.Duration{Duration: alive.Round(time.Second)}
+	}
+
+	var awarded []achievement
+	for _, a := range petAchievements {
+		if !slices.Contains(pet.Status.Achievements, a.name) && a.reached(pet, now) {
+			pet.Status.Achievements = append(pet.Status.Achievements, a.name)
+			awarded = append(awarded, a.achievement)
+		}
+	}
+	return awarded
+}
+
+// recordPetAchievements emits an event for every achievement pet was awarded.
+func (r *PetReconciler) recordPetAchievements(ctx context.Context, prev linuxfestv2025.PetStatus, pet *linuxfestv2025.Pet, awarded []achievement) {
+	for _, a := range awarded {
+		achievementsAwarded.WithLabelValues(pet.Namespace, a.name).Inc()
+
+		annotations := valueAnnotations(prev, pet.Status)
+		annotations[annotationAchievement] = a.name
+		r.event(ctx, pet, annotations, corev1.EventTypeNormal, "AchievementUnlocked",
+			fmt.Sprintf("🏆 %s unlocked %q", pet.Spec.Nickname, a.title))
+	}
+}
+
+// CareTally counts the feedings and pettings of caretakers until the
+// LeaderboardReconciler adds them to the leaderboard of their namespace, so
+// caring for a pet does not cost a leaderboard write. Its zero value is ready to use.
+type CareTally struct {
+	mu sync.Mutex
+	// care holds the care of every caretaker by namespace.
+	care map[string]map[string]care
+}
+
+// care is what a caretaker did since the tally was last taken.
+type care struct {
+	feedings int
+	pettings int
+	last     time.Time
+}
+
+// add counts a feeding and/or petting by caretaker in namespace at now.
+func (t *CareTally) add(namespace, caretaker string, fed, petted bool, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.care == nil {
+		t.care = map[string]map[string]care{}
+	}
+	if t.care[namespace] == nil {
+		t.care[namespace] = map[string]care{}
+	}
+
+	c := t.care[namespace][caretaker]
+	if fed {
+		c.feedings++
+	}
+	if petted {
+		c.pettings++
+	}
+	c.last = now
+	t.care[namespace][caretaker] = c
+}
+
+// take removes and returns the care counted in namespace.
+func (t *CareTally) take(namespace string) map[string]care {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	taken := t.care[namespace]
+	delete(t.care, namespace)
+	return taken
+}
+
+// giveBack counts care taken from namespace again, when it could not be written.
+func (t *CareTally) giveBack(namespace string, taken map[string]care) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.care == nil {
+		t.care = map[string]map[string]care{}
+	}
+	if t.care[namespace] == nil {
+		t.care[namespace] = map[string]care{}
+	}
+	for caretaker, c := range taken {
+		counted := t.care[namespace][caretaker]
+		counted.feedings += c.feedings
+		counted.pettings += c.pettings
+		if c.last.After(counted.last) {
+			counted.last = c.last
+		}
+		t.care[namespace][caretaker] = counted
+	}
+}
+
+// recordCare counts a feeding and/or petting of pet by caretaker towards the
+// leaderboard of its namespace.
+func (r *PetReconciler) recordCare(pet *linuxfestv2025.Pet, caretaker string, fed, petted bool) {
+	if caretaker == "" {
+		return
+	}
+	if fed {
+		caretakerActions.WithLabelValues(pet.Namespace, "feed").Inc()
+	}
+	if petted {
+		caretakerActions.WithLabelValues(pet.Namespace, "pet").Inc()
+	}
+	if r.Care != nil {
+		r.Care.add(pet.Namespace, caretaker, fed, petted, r.now())
+	}
+}
+
+// caretakerAward is an achievement reached by a caretaker.
+type caretakerAward struct {
+	achievement
+	caretaker string
+}
+
+// addCare adds taken to the caretakers of status, keeps the size most caring
+// ones and returns the achievements the caretakers reached.
+func addCare(status *linuxfestv2025.LeaderboardStatus, taken map[string]care, size int) []caretakerAward {
+	names := make([]string, 0, len(taken))
+	for name := range taken {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var awarded []caretakerAward
+	for _, name := range names {
+		c := taken[name]
+
+		i := slices.IndexFunc(status.Caretakers, func(c linuxfestv2025.CaretakerStatus) bool { return c.Name == name })
+		if i < 0 {
+			status.Caretakers = append(status.Caretakers, linuxfestv2025.CaretakerStatus{Name: name})
+			i = len(status.Caretakers) - 1
+		}
+
+		stats := &status.Caretakers[i]
+		stats.Feedings += c.feedings
+		stats.Pettings += c.pettings
+		if c.last.After(stats.LastCareTime.Time) {
+			stats.LastCareTime = v1.NewTime(c.last)
+		}
+
+		for _, a := range caretakerAchievements {
+			if !slices.Contains(stats.Achievements, a.name) && a.reached(stats) {
+				stats.Achievements = append(stats.Achievements, a.name)
+				awarded = append(awarded, caretakerAward{achievement: a.achievement, caretaker: name})
+			}
+		}
+	}
+
+	// 🥇 The most caring caretakers first, and only the best make it onto the leaderboard
+	sort.SliceStable(status.Caretakers, func(i, j int) bool {
+		ci, cj := status.Caretakers[i], status.Caretakers[j]
+		return ci.Feedings+ci.Pettings > cj.Feedings+cj.Pettings
+	})
+	status.Caretakers = status.Caretakers[:min(len(status.Caretakers), size)]
+	return awarded
+}
+
+// updateLeaderboard applies update to the status of the leaderboard in
+// namespace, creating the leaderboard when it does not exist yet. It returns
+// the updated leaderboard.
+func updateLeaderboard(ctx context.Context, c client.Client, namespace string, update func(*linuxfestv2025.LeaderboardStatus)) (*linuxfestv2025.Leaderboard, error) {
+	key := client.ObjectKey{Namespace: namespace, Name: linuxfestv2025.LeaderboardName}
+
+	var updated *linuxfestv2025.Leaderboard
+	err := retry.OnError(retry.DefaultRetry, retriable, func() error {
+		var leaderboard linuxfestv2025.Leaderboard
+		if err := c.Get(ctx, key, &leaderboard); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return err
+			}
+
+			leaderboard = linuxfestv2025.Leaderboard{ObjectMeta: v1.ObjectMeta{Namespace: namespace, Name: key.Name}}
+			if err := c.Create(ctx, &leaderboard); err != nil {
+				return err
+			}
+		}
+
+		cpy := leaderboard.DeepCopy()
+		update(&cpy.Status)
+		if err := c.Status().Update(ctx, cpy); err != nil {
+			return err
+		}
+		updated = cpy
+		return nil
+	})
+	return updated, err
+}
+
+// retriable reports whether an update failed because it raced with another writer.
+func retriable(err error) bool {
+	return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+}
+
+// +kubebuilder:rbac:groups=linuxfest.example.com,resources=leaderboards,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=linuxfest.example.com,resources=leaderboards/status,verbs=get;update;patch
+
+// Defaults of the LeaderboardReconciler.
+const (
+	defaultLeaderboardSize     = 10
+	defaultLeaderboardInterval = time.Minute
+)
+
+// LeaderboardReconciler ranks the pets of a namespace by their longest survival
+// streak and their caretakers by the care they gave. Both change often, so
+// rather than on every pet change or action the leaderboard is refreshed every
+// Interval, and when pets are added or removed.
+type LeaderboardReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Recorder records the achievements of caretakers on their leaderboard.
+	Recorder record.EventRecorder
+
+	// Care is the care counted by the PetReconciler, it is added to the leaderboards.
+	Care *CareTally
+
+	// DryRun logs the writes and events of every reconcile instead of making them.
+	DryRun bool
+
+	// Size is the number of survivors and caretakers kept on a leaderboard, 10 when zero.
+	Size int
+
+	// Interval is how often a leaderboard is refreshed, a minute when zero.
+	Interval time.Duration
+
+	// Shards limits the reconciler to the leaderboards of the shards this
+	// replica holds, every leaderboard is refreshed when nil.
+	Shards *sharding.Coordinator
+}
+
+// Reconcile recomputes the survivors of the leaderboard in req's namespace.
+func (r *LeaderboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	// 🧩 Another replica ranks the survivors of this leaderboard, look again on
+	// the next refresh in case its shard moved here by then. Every replica adds
+	// the care it counted.
+	if r.Shards != nil && !r.Shards.Owns(req.NamespacedName) {
+		longestSurvival.DeletePartialMatch(map[string]string{"namespace": req.Namespace})
+		if err := r.update(ctx, req.Namespace, nil); err != nil {
+			log.Error(err, "unable to update leaderboard")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: r.interval()}, nil
+	}
+
+	var pets linuxfestv2025.PetList
+	if err := r.List(ctx, &pets, client.InNamespace(req.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+	survivors := make([]linuxfestv2025.SurvivorStatus, 0, len(pets.Items))
+	for i := range pets.Items {
+		pet := &pets.Items[i]
+		survivors = append(survivors, linuxfestv2025.SurvivorStatus{
+			Pet:             pet.Name,
+			LongestSurvival: pet.Status.LongestSurvival,
+			Alive:           !isDead(pet),
+		})
+	}
+	sort.SliceStable(survivors, func(i, j int) bool {
+		return survivors[i].LongestSurvival.Duration > survivors[j].LongestSurvival.Duration
+	})
+
+	// 🥇 Only the best make it onto the leaderboard
+	survivors = survivors[:min(len(survivors), r.size())]
+
+	// 📊 Forget pets that were deleted or fell off the leaderboard
+	longestSurvival.DeletePartialMatch(map[string]string{"namespace": req.Namespace})
+	for _, survivor := range survivors {
+		longestSurvival.WithLabelValues(req.Namespace, survivor.Pet).Set(survivor.LongestSurvival.Seconds())
+	}
+
+	if err := r.update(ctx, req.Namespace, survivors); err != nil {
+		log.Error(err, "unable to update leaderboard")
+		return ctrl.Result{}, err
+	}
+
+	// 💤 Namespaces without pets are refreshed again once a pet is created
+	if len(pets.Items) == 0 {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: r.interval()}, nil
+}
+
+// update adds the care counted in namespace to its leaderboard and replaces its
+// survivors unless they are nil. Care that can not be written is added next time.
+func (r *LeaderboardReconciler) update(ctx context.Context, namespace string, survivors []linuxfestv2025.SurvivorStatus) error {
+	var taken map[string]care
+	if r.Care != nil {
+		taken = r.Care.take(namespace)
+	}
+	if survivors == nil && len(taken) == 0 {
+		return nil
+	}
+
+	var awarded []caretakerAward
+	leaderboard, err := updateLeaderboard(ctx, r.Client, namespace, func(status *linuxfestv2025.LeaderboardStatus) {
+		if survivors != nil {
+			status.Survivors = survivors
+		}
+		awarded = addCare(status, taken, r.size())
+	})
+	if err != nil {
+		if r.Care != nil && len(taken) > 0 {
+			r.Care.giveBack(namespace, taken)
+		}
+		return err
+	}
+
+	for _, a := range awarded {
+		achievementsAwarded.WithLabelValues(namespace, a.name).Inc()
+		r.Recorder.AnnotatedEventf(leaderboard,
+			map[string]string{annotationAchievement: a.name, annotationCaretaker: a.caretaker},
+			corev1.EventTypeNormal, "AchievementUnlocked", "🏆 %s unlocked %q", a.caretaker, a.title)
+	}
+	return nil
+}
+
+// size returns the number of survivors and caretakers kept on a leaderboard.
+func (r *LeaderboardReconciler) size() int {
+	if r.Size <= 0 {
+		return defaultLeaderboardSize
+	}
+	return r.Size
+}
+
+// interval returns how often a leaderboard is refreshed.
+func (r *LeaderboardReconciler) interval() time.Duration {
+	if r.Interval <= 0 {
+		return defaultLeaderboardInterval
+	}
+	return r.Interval
+}
+
+// leaderboardOf enqueues the leaderboard of a pet's namespace.
+func leaderboardOf(_ context.Context, obj client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{
+		Namespace: obj.GetNamespace(),
+		Name:      linuxfestv2025.LeaderboardName,
+	}}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LeaderboardReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// 👻 Work out every change without making it
+	if r.DryRun {
+		r.Client = NewDryRunClient(r.Client)
+		r.Recorder = newDryRunRecorder()
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor("leaderboard-controller")
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("leaderboard").
+		// 🏁 New and deleted pets start and reorder the leaderboard of their
+		// namespace, updates are picked up by the periodic refresh
+		Watches(&linuxfestv2025.Pet{}, handler.EnqueueRequestsFromMapFunc(leaderboardOf),
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc:  func(event.UpdateEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+			})).
+		Complete(r)
+}
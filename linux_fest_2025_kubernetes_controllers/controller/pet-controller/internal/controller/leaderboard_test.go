@@ -0,0 +1,213 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/sharding"
+)
+
+var _ = Describe("updateSurvival", func() {
+	now := time.Now()
+
+	petAliveFor := func(alive, notHungry time.Duration) *linuxfestv2025.Pet {
+		return &linuxfestv2025.Pet{Status: linuxfestv2025.PetStatus{Conditions: []metav1.Condition{
+			{Type: linuxfestv2025.PetConditionDead, Status: metav1.ConditionFalse, LastTransitionTime: metav1.NewTime(now.Add(-alive))},
+			{Type: linuxfestv2025.PetConditionHungry, Status: metav1.ConditionFalse, LastTransitionTime: metav1.NewTime(now.Add(-notHungry))},
+		}}}
+	}
+
+	It("should extend the longest survival streak", func() {
+		pet := petAliveFor(time.Hour, time.Hour)
+		pet.Status.LongestSurvival = metav1.Duration{Duration: time.Minute}
+
+		Expect(updateSurvival(pet, now)).To(BeEmpty())
+		Expect(pet.Status.LongestSurvival.Duration).To(Equal(time.Hour))
+	})
+
+	It("should keep a longer streak of a previous life", func() {
+		pet := petAliveFor(time.Hour, time.Hour)
+		pet.Status.LongestSurvival = metav1.Duration{Duration: 48 * time.Hour}
+
+		updateSurvival(pet, now)
+		Expect(pet.Status.LongestSurvival.Duration).To(Equal(48 * time.Hour))
+	})
+
+	It("should award each achievement once", func() {
+		pet := petAliveFor(31*24*time.Hour, 8*24*time.Hour)
+
+		Expect(updateSurvival(pet, now)).To(HaveLen(2))
+		Expect(pet.Status.Achievements).To(ConsistOf("NoHunger7d", "Survivor30d"))
+		Expect(updateSurvival(pet, now)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("LeaderboardReconciler", func() {
+	ctx := context.Background()
+
+	It("should keep only the longest survivors and refresh periodically", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(linuxfestv2025.AddToScheme(scheme)).To(Succeed())
+
+		clientBuilder := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&linuxfestv2025.Leaderboard{})
+		for i := range 5 {
+			clientBuilder.WithObjects(&linuxfestv2025.Pet{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pet-%d", i), Namespace: "default"},
+				Status:     linuxfestv2025.PetStatus{LongestSurvival: metav1.Duration{Duration: time.Duration(i) * time.Hour}},
+			})
+		}
+		c := clientBuilder.Build()
+
+		r := &LeaderboardReconciler{Client: c, Scheme: scheme, Size: 3, Interval: 30 * time.Second}
+		key := client.ObjectKey{Namespace: "default", Name: linuxfestv2025.LeaderboardName}
+		Expect(r.Reconcile(ctx, ctrl.Request{NamespacedName: key})).To(Equal(ctrl.Result{RequeueAfter: 30 * time.Second}))
+
+		var leaderboard linuxfestv2025.Leaderboard
+		Expect(c.Get(ctx, key, &leaderboard)).To(Succeed())
+		Expect(leaderboard.Status.Survivors).To(HaveExactElements(
+			HaveField("Pet", "pet-4"),
+			HaveField("Pet", "pet-3"),
+			HaveField("Pet", "pet-2"),
+		))
+	})
+
+	It("should add the counted care and keep only the most caring caretakers", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(linuxfestv2025.AddToScheme(scheme)).To(Succeed())
+
+		key := client.ObjectKey{Namespace: "default", Name: linuxfestv2025.LeaderboardName}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&linuxfestv2025.Leaderboard{}).
+			WithObjects(
+				&linuxfestv2025.Pet{ObjectMeta: metav1.ObjectMeta{Name: "rex", Namespace: "default"}},
+				&linuxfestv2025.Leaderboard{
+					ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+					Status: linuxfestv2025.LeaderboardStatus{
+						Caretakers: []linuxfestv2025.CaretakerStatus{{Name: "erin", Feedings: 99}},
+					},
+				},
+			).
+			Build()
+
+		now := time.Now()
+		care := &CareTally{}
+		for range 3 {
+			care.add("default", "alice", true, false, now)
+		}
+		care.add("default", "bob", false, true, now)
+		care.add("default", "erin", true, false, now)
+
+		recorder := record.NewFakeRecorder(10)
+		r := &LeaderboardReconciler{Client: c, Scheme: scheme, Recorder: recorder, Care: care, Size: 2}
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		var leaderboard linuxfestv2025.Leaderboard
+		Expect(c.Get(ctx, key, &leaderboard)).To(Succeed())
+		Expect(leaderboard.Status.Caretakers).To(HaveExactElements(
+			And(HaveField("Name", "erin"), HaveField("Feedings", 100), HaveField("Achievements", ConsistOf("Fed100"))),
+			And(HaveField("Name", "alice"), HaveField("Feedings", 3)),
+		))
+		Expect(recorder.Events).To(Receive(ContainSubstring(`erin unlocked "Fed 100 times"`)))
+
+		By("writing the care only once")
+		Expect(care.take("default")).To(BeEmpty())
+	})
+
+	It("should clear the survivors once every pet is gone", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(linuxfestv2025.AddToScheme(scheme)).To(Succeed())
+
+		key := client.ObjectKey{Namespace: "default", Name: linuxfestv2025.LeaderboardName}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&linuxfestv2025.Leaderboard{}).
+			WithObjects(&linuxfestv2025.Leaderboard{
+				ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+				Status: linuxfestv2025.LeaderboardStatus{
+					Survivors: []linuxfestv2025.SurvivorStatus{{Pet: "rex"}},
+				},
+			}).
+			Build()
+
+		r := &LeaderboardReconciler{Client: c, Scheme: scheme}
+		Expect(r.Reconcile(ctx, ctrl.Request{NamespacedName: key})).To(Equal(ctrl.Result{}))
+
+		var leaderboard linuxfestv2025.Leaderboard
+		Expect(c.Get(ctx, key, &leaderboard)).To(Succeed())
+		Expect(leaderboard.Status.Survivors).To(BeEmpty())
+	})
+
+	It("should leave the leaderboards of other shards alone", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(linuxfestv2025.AddToScheme(scheme)).To(Succeed())
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&linuxfestv2025.Leaderboard{}).
+			WithObjects(&linuxfestv2025.Pet{ObjectMeta: metav1.ObjectMeta{Name: "rex", Namespace: "default"}}).
+			Build()
+		shards, err := sharding.NewCoordinator(c, c, sharding.Options{
+			Namespace: "default", Group: "pets", Identity: "replica-0", Shards: 2,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		r := &LeaderboardReconciler{Client: c, Scheme: scheme, Interval: 30 * time.Second, Shards: shards}
+		key := client.ObjectKey{Namespace: "default", Name: linuxfestv2025.LeaderboardName}
+
+		By("waiting for the shard while another replica holds it")
+		Expect(r.Reconcile(ctx, ctrl.Request{NamespacedName: key})).To(Equal(ctrl.Result{RequeueAfter: 30 * time.Second}))
+		var leaderboard linuxfestv2025.Leaderboard
+		Expect(apierrors.IsNotFound(c.Get(ctx, key, &leaderboard))).To(BeTrue())
+
+		By("refreshing it once the shard moved here")
+		Expect(shards.Rebalance(ctx)).To(Succeed())
+		Expect(r.Reconcile(ctx, ctrl.Request{NamespacedName: key})).To(Equal(ctrl.Result{RequeueAfter: 30 * time.Second}))
+		Expect(c.Get(ctx, key, &leaderboard)).To(Succeed())
+		Expect(leaderboard.Status.Survivors).To(HaveExactElements(HaveField("Pet", "rex")))
+	})
+})
+
+var _ = Describe("CareTally", func() {
+	It("should count care again that could not be written", func() {
+		now := time.Now()
+		var tally CareTally
+		tally.add("default", "alice", true, true, now)
+
+		taken := tally.take("default")
+		tally.add("default", "alice", true, false, now.Add(time.Second))
+		tally.giveBack("default", taken)
+
+		Expect(tally.take("default")).To(Equal(map[string]care{
+			"alice": {feedings: 2, pettings: 1, last: now.Add(time.Second)},
+		}))
+	})
+})
@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// caretakerActions counts the feedings and pettings credited to caretakers.
+	// Caretakers are not a label, there is one per user; the leaderboard ranks them.
+	caretakerActions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pet_caretaker_actions_total",
+		Help: "Number of times caretakers fed or petted a pet.",
+	}, []string{"namespace", "action"})
+
+	// longestSurvival is the longest survival streak of the pets on a leaderboard.
+	longestSurvival = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pet_longest_survival_seconds",
+		Help: "Longest time a pet on the leaderboard stayed alive in one go.",
+	}, []string{"namespace", "pet"})
+
+	// achievementsAwarded counts the achievements awarded to pets and caretakers.
+	achievementsAwarded = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pet_achievements_awarded_total",
+		Help: "Number of achievements awarded to pets and caretakers.",
+	}, []string{"namespace", "achievement"})
+
+	// rateLimitedActions counts the feed and pet requests dropped by rate limiting.
+	rateLimitedActions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pet_rate_limited_actions_total",
+		Help: "Number of feed and pet requests dropped because a pet or caretaker exceeded its rate limit.",
+	}, []string{"namespace", "limit"})
+
+	// decayQueueLength is the number of pets waiting in the decay queue.
+	decayQueueLength = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "pet_decay_queue_length",
+		Help: "Number of pets waiting in the decay queue.",
+	})
+
+	// decayBacklog is the number of pets whose decay is overdue.
+	decayBacklog = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "pet_decay_backlog",
+		Help: "Number of pets that are due to decay but have not been decayed yet.",
+	})
+
+	// decayBatchSize is the number of pets decayed in every batch.
+	decayBatchSize = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "pet_decay_batch_size",
+		Help:    "Number of due pets taken from the decay queue at once.",
+		Buckets: prometheus.ExponentialBuckets(1, 4, 7),
+	})
+
+	// decayWrites counts the status writes of decaying pets by result.
+	decayWrites = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pet_decay_writes_total",
+		Help: "Number of status writes made to decay pets, by result.",
+	}, []string{"result"})
+
+	// decayLag is how long after it was due every decay was applied.
+	decayLag = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "pet_decay_lag_seconds",
+		Help:    "Time between when a pet was due to decay and when its decay was applied.",
+		Buckets: prometheus.ExponentialBuckets(0.01, 3, 10),
+	})
+
+	// decayLagMax is the largest decay lag of every namespace over the last lagWindow.
+	decayLagMax = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "pet_decay_lag_max_seconds",
+		Help: "Largest time a pet of the namespace was decayed after it was due, over the last minute.",
+	}, []string{"namespace"})
+
+	// decayMissedIntervals counts the whole decay intervals that passed without a decay.
+	decayMissedIntervals = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pet_decay_missed_intervals_total",
+		Help: "Number of decay intervals that passed without the pet being decayed, pets decay once however late they are.",
+	}, []string{"namespace"})
+
+	// dryRunWrites counts the writes skipped in dry run mode.
+	dryRunWrites = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pet_dry_run_writes_total",
+		Help: "Number of writes the controller would have made in dry run mode.",
+	}, []string{"verb", "kind", "subresource"})
+
+	// dryRunEvents counts the events skipped in dry run mode.
+	dryRunEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "pet_dry_run_events_total",
+		Help: "Number of events the controller would have recorded in dry run mode.",
+	}, []string{"type", "reason"})
+
+	// reconcileDelay is the delay the rate limiter puts on every requeued pet.
+	reconcileDelay = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "pet_reconcile_rate_limit_delay_seconds",
+		Help:    "Delay the rate limiter put on pets requeued after an error or by the overall limit.",
+		Buckets: prometheus.ExponentialBuckets(0.005, 4, 10),
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(caretakerActions, longestSurvival, achievementsAwarded, rateLimitedActions,
+		decayQueueLength, decayBacklog, decayBatchSize, decayWrites, reconcileDelay,
+		decayLag, decayLagMax, decayMissedIntervals, dryRunWrites, dryRunEvents)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ReconcileOptions tunes the workers and the work queue of the pet controller.
+// The zero value matches the controller-runtime defaults.
+type ReconcileOptions struct {
+	// MaxConcurrentReconciles is the number of pets reconciled at the same time, 1 when zero.
+	MaxConcurrentReconciles int
+
+	// BaseDelay is the first delay before a pet whose reconcile failed is retried,
+	// it doubles on every failure in a row. 5ms when zero.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the retry delay of a single pet, 1000s when zero.
+	MaxDelay time.Duration
+
+	// QPS is how many pets per second may be queued overall, 10 when zero.
+	QPS float64
+
+	// Burst is how many pets may be queued at once above QPS, 100 when zero.
+	Burst int
+
+	// RecoverPanic turns a panicking reconcile into an error instead of crashing
+	// the manager. The manager's setting, which recovers, is used when nil.
+	RecoverPanic *bool
+}
+
+// DefaultReconcileOptions returns the options the pet controller runs with by default.
+func DefaultReconcileOptions() ReconcileOptions {
+	return ReconcileOptions{
+		MaxConcurrentReconciles: 1,
+		BaseDelay:               5 * time.Millisecond,
+		MaxDelay:                1000 * time.Second,
+		QPS:                     10,
+		Burst:                   100,
+		RecoverPanic:            ptr.To(true),
+	}
+}
+
+// controllerOptions returns the controller options for o.
+func (o ReconcileOptions) controllerOptions() controller.Options {
+	defaults := DefaultReconcileOptions()
+	if o.MaxConcurrentReconciles <= 0 {
+		o.MaxConcurrentReconciles = defaults.MaxConcurrentReconciles
+	}
+	if o.BaseDelay <= 0 {
+		o.BaseDelay = defaults.BaseDelay
+	}
+	if o.MaxDelay <= 0 {
+		o.MaxDelay = defaults.MaxDelay
+	}
+	if o.QPS <= 0 {
+		o.QPS = defaults.QPS
+	}
+	if o.Burst <= 0 {
+		o.Burst = defaults.Burst
+	}
+
+	return controller.Options{
+		MaxConcurrentReconciles: o.MaxConcurrentReconciles,
+		RecoverPanic:            o.RecoverPanic,
+		RateLimiter: observedRateLimiter{workqueue.NewTypedMaxOfRateLimiter(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](o.BaseDelay, o.MaxDelay),
+			&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(o.QPS), o.Burst)},
+		)},
+	}
+}
+
+// observedRateLimiter records the delays handed out by its rate limiter.
+type observedRateLimiter struct {
+	workqueue.TypedRateLimiter[reconcile.Request]
+}
+
+func (l observedRateLimiter) When(req reconcile.Request) time.Duration {
+	delay := l.TypedRateLimiter.When(req)
+	reconcileDelay.Observe(delay.Seconds())
+	return delay
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var _ = Describe("ReconcileOptions", func() {
+	request := func(name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}}
+	}
+
+	It("should back off a failing pet exponentially", func() {
+		limiter := ReconcileOptions{BaseDelay: time.Second, MaxDelay: 4 * time.Second, QPS: 1000, Burst: 1000}.
+			controllerOptions().RateLimiter
+
+		rex := request("rex")
+		var delays []time.Duration
+		for range 4 {
+			delays = append(delays, limiter.When(rex))
+		}
+		Expect(delays).To(Equal([]time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second}))
+		Expect(limiter.When(request("mochi"))).To(Equal(time.Second))
+
+		limiter.Forget(rex)
+		Expect(limiter.When(rex)).To(Equal(time.Second))
+	})
+
+	It("should limit how fast pets are queued overall", func() {
+		limiter := ReconcileOptions{BaseDelay: time.Millisecond, QPS: 1, Burst: 2}.controllerOptions().RateLimiter
+
+		Expect(limiter.When(request("a"))).To(Equal(time.Millisecond))
+		Expect(limiter.When(request("b"))).To(Equal(time.Millisecond))
+		Expect(limiter.When(request("c"))).To(BeNumerically("~", time.Second, 100*time.Millisecond))
+	})
+
+	It("should fall back to the controller-runtime defaults", func() {
+		opts := ReconcileOptions{}.controllerOptions()
+		Expect(opts.MaxConcurrentReconciles).To(Equal(1))
+		Expect(opts.RecoverPanic).To(BeNil())
+		Expect(opts.RateLimiter.When(request("rex"))).To(Equal(5 * time.Millisecond))
+	})
+
+	Context("under load", func() {
+		var (
+			mgr      ctrl.Manager
+			requests chan event.TypedGenericEvent[reconcile.Request]
+		)
+
+		// run starts a controller with opts that reconciles with fn until the spec ends.
+		run := func(name string, opts ReconcileOptions, fn reconcile.Func) {
+			var err error
+			// 🔌 Nothing is watched, the manager never talks to the API server
+			mgr, err = ctrl.NewManager(&rest.Config{Host: "http://127.0.0.1:1"}, ctrl.Options{
+				Metrics: metricsserver.Options{BindAddress: "0"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			controllerOpts := opts.controllerOptions()
+			controllerOpts.Reconciler = fn
+			controllerOpts.SkipNameValidation = ptr.To(true)
+			c, err := controller.New(name, mgr, controllerOpts)
+			Expect(err).NotTo(HaveOccurred())
+
+			requests = make(chan event.TypedGenericEvent[reconcile.Request])
+			Expect(c.Watch(source.TypedChannel(requests, handler.TypedFuncs[reconcile.Request, reconcile.Request]{
+				GenericFunc: func(_ context.Context, e event.TypedGenericEvent[reconcile.Request], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+					q.Add(e.Object)
+				},
+			}))).To(Succeed())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			DeferCleanup(cancel)
+			go func() {
+				defer GinkgoRecover()
+				Expect(mgr.Start(ctx)).To(Succeed())
+			}()
+		}
+
+		It("should reconcile up to max-concurrent-reconciles pets at once", func() {
+			var (
+				active, peak atomic.Int32
+				mu           sync.Mutex
+				reconciled   []string
+			)
+			run("load", ReconcileOptions{MaxConcurrentReconciles: 4, QPS: 1000, Burst: 1000},
+				func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+					n := active.Add(1)
+					defer active.Add(-1)
+					for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+					}
+					time.Sleep(50 * time.Millisecond)
+
+					mu.Lock()
+					defer mu.Unlock()
+					reconciled = append(reconciled, req.Name)
+					return reconcile.Result{}, nil
+				})
+
+			start := time.Now()
+			for i := range 40 {
+				requests <- event.TypedGenericEvent[reconcile.Request]{Object: request(fmt.Sprintf("pet-%d", i))}
+			}
+			Eventually(func() int {
+				mu.Lock()
+				defer mu.Unlock()
+				return len(reconciled)
+			}).WithTimeout(5 * time.Second).Should(Equal(40))
+
+			// ⏱️ 40 pets taking 50ms each are done in 10 rounds of 4
+			Expect(peak.Load()).To(BeEquivalentTo(4))
+			Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		})
+
+		It("should retry a panicking reconcile when recover-panic is set", func() {
+			var calls atomic.Int32
+			run("panic", ReconcileOptions{RecoverPanic: ptr.To(true), BaseDelay: time.Millisecond},
+				func(context.Context, reconcile.Request) (reconcile.Result, error) {
+					if calls.Add(1) == 1 {
+						panic("🐍 bitten")
+					}
+					return reconcile.Result{}, nil
+				})
+
+			requests <- event.TypedGenericEvent[reconcile.Request]{Object: request("rex")}
+			Eventually(calls.Load).WithTimeout(5 * time.Second).Should(BeEquivalentTo(2))
+		})
+	})
+})
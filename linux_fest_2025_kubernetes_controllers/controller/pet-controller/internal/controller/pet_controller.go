@@ -20,196 +20,609 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/config"
+	"github.com/itzloop/pet-controller/internal/notifier"
+	"github.com/itzloop/pet-controller/internal/sharding"
+	"github.com/itzloop/pet-controller/pkg/engine"
+	"github.com/itzloop/pet-controller/pkg/engine/kube"
+	"github.com/itzloop/pet-controller/pkg/history"
 )
 
+// tracer creates the spans around each reconcile phase.
+var tracer = otel.Tracer("github.com/itzloop/pet-controller/internal/controller")
+
 // PetReconciler reconciles a Pet object
 type PetReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 
 	Recorder record.EventRecorder // 👈 Add this
+
+	// Notifier forwards warnings to the sinks of matching NotificationPolicies.
+	Notifier *notifier.PetNotifier
+
+	// Config holds the gameplay tunables, the defaults are used when nil.
+	Config *config.Store
+
+	// History records the samples of every pet when history is enabled.
+	History *history.Recorder
+
+	// Clock tells the time, the real clock is used when nil.
+	Clock clock.PassiveClock
+
+	// Decay tunes the scheduler that decays due pets.
+	Decay DecayOptions
+
+	// Options tunes the workers and the rate limiter of the controller.
+	Options ReconcileOptions
+
+	// DryRun logs the writes, events and alerts of every reconcile instead of
+	// making them, so a new release can shadow the active one.
+	DryRun bool
+
+	// Care counts the care of caretakers for the LeaderboardReconciler,
+	// caretakers are not credited when nil.
+	Care *CareTally
+
+	// TrustRequester credits actions to the requester the admission webhook
+	// stamped on the pet. Leave it off when the webhook is not deployed, anyone
+	// allowed to update pets can then set the requester annotation.
+	TrustRequester bool
+
+	// Shards limits the reconciler to the pets of the shards this replica
+	// holds, every pet is reconciled when nil.
+	Shards *sharding.Coordinator
+
+	// scheduler decays pets when they are due.
+	scheduler *decayScheduler
+
+	// limiter throttles the feed and pet actions of pets and caretakers.
+	limiter actionLimiter
+
+	// rejections counts the actions the limiter dropped until they are written.
+	rejections rejections
+
+	// lags tracks how late pets are decayed.
+	lags decayLags
 }
 
 // +kubebuilder:rbac:groups=linuxfest.example.com,resources=pets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=linuxfest.example.com,resources=pets/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=linuxfest.example.com,resources=pets/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop
 func (r *PetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	log := log.FromContext(ctx)
+	ctx, span := tracer.Start(ctx, "Reconcile", trace.WithAttributes(
+		attribute.String("pet.name", req.Name),
+		attribute.String("pet.namespace", req.Namespace),
+	))
+	defer span.End()
+
+	// 🧩 Leave the pets of other shards to the replicas holding them
+	if !r.owns(req.NamespacedName) {
+		r.scheduler.forget(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
 
 	// 🐾 Fetch the Pet resource
 	var pet linuxfestv2025.Pet
 	if err := r.Get(ctx, client.ObjectKey{Name: req.Name, Namespace: req.Namespace}, &pet); err != nil {
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		if errors.IsNotFound(err) {
+			r.limiter.forget("pet/" + req.String())
+			r.rejections.forget(req.String())
+		}
+		return ctrl.Result{}, recordError(span, client.IgnoreNotFound(err))
 	}
 
-	// 🐣 First-time initialization (100 food + love)
-	if !pet.Status.Initialized && pet.Status.Food == 0 && pet.Status.Love == 0 {
-		fmt.Println("init", pet.Status.Initialized, pet.Status.Food, pet.Status.Love)
-
-		for range 10 {
-			petCopy := pet.DeepCopy()
-			petCopy.Status.Food = 100
-			petCopy.Status.Love = 100
-			pet.Status.ModifiedTime = v1.Now()
-			petCopy.Status.Initialized = true
-
-			// 💾 Save initial state
-			if err := r.Status().Update(ctx, petCopy); err != nil {
-				log.Error(err, "unable to update status")
-
-				if errors.IsConflict(err) {
-					// 🔁 Retry if needed
-					if err := r.Get(ctx, client.ObjectKey{Name: req.Name, Namespace: req.Namespace}, &pet); err != nil {
-						return ctrl.Result{}, client.IgnoreNotFound(err)
-					}
-					continue
-				} else if errors.IsNotFound(err) {
-					return ctrl.Result{}, nil
-				}
+	log := log.FromContext(ctx).WithValues(
+		"pet", pet.Spec.Nickname,
+		"generation", pet.Generation,
+		"resourceVersion", pet.ResourceVersion,
+	)
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	// 📦 Don't touch pets whose status is being restored from a backup
+	if _, ok := pet.Annotations[linuxfestv2025.RestoringAnnotation]; ok {
+		log.V(1).Info("Pet is being restored, skipping")
+		return ctrl.Result{}, nil
+	}
 
-				return ctrl.Result{RequeueAfter: petCopy.Spec.DecayInterval.Duration}, err
-			}
+	// 🏷️ Show which shard the pet belongs to
+	if err := r.labelShard(ctx, &pet); err != nil {
+		log.Error(err, "unable to label shard")
+		return ctrl.Result{}, recordError(span, err)
+	}
 
-			// 🕐 Schedule next decay
-			return ctrl.Result{RequeueAfter: petCopy.Spec.DecayInterval.Duration}, nil
-		}
+	// ⚙️ Use the same configuration for the whole reconcile, even if it is reloaded meanwhile
+	cfg := r.config()
+
+	// 🐣 First-time initialization (initial food + love)
+	if !pet.Status.Initialized && pet.Status.Food == 0 && pet.Status.Love == 0 {
+		result, err := r.initialize(ctx, &pet, cfg)
+		return result, recordError(span, err)
 	}
 
 	// 🔍 Log the reconcile trigger
-	fmt.Println("Reconciling", pet.Name, "gen:", pet.Generation, "rv:", pet.ResourceVersion)
+	log.V(1).Info("Reconciling", "food", pet.Status.Food, "love", pet.Status.Love)
 
-	// 🎯 Check if we should skip reconcile (not enough time passed, no annotations)
-	_, feedAnnot := pet.Annotations["linuxfest.example.com/feed"]
-	_, petAnnot := pet.Annotations["linuxfest.example.com/pet"]
-	if !feedAnnot && !petAnnot && time.Since(pet.Status.ModifiedTime.Time) < pet.Spec.DecayInterval.Duration {
-		return ctrl.Result{RequeueAfter: pet.Spec.DecayInterval.Duration}, nil
-	} else if !feedAnnot && !petAnnot && time.Since(pet.Status.ModifiedTime.Time) >= pet.Spec.DecayInterval.Duration {
-		if pet.Status.Food == 0 {
-			return ctrl.Result{RequeueAfter: pet.Spec.DecayInterval.Duration}, nil
-		}
+	// 🩹 Pets born before health existed start healthy
+	if err := r.backfillHealth(ctx, &pet, cfg); err != nil {
+		log.Error(err, "unable to backfill health")
+		return ctrl.Result{}, recordError(span, err)
+	}
+
+	// 💊 Apply pending treatments
+	if err := r.treat(ctx, &pet, cfg); err != nil {
+		log.Error(err, "unable to apply treatments")
+		return ctrl.Result{}, recordError(span, err)
+	}
+
+	// 🪄 Bring dead pets back to life on request
+	if _, ok := pet.Annotations[cfg.Annotations.Revive]; ok {
+		result, err := r.revive(ctx, &pet, cfg)
+		return result, recordError(span, err)
+	}
+
+	// 🐶 Grieve friends that died and find out who is around to play with
+	friends, err := r.friends(ctx, &pet)
+	if err != nil {
+		return ctrl.Result{}, recordError(span, err)
+	}
+	if err := r.mourn(ctx, &pet, friends, cfg); err != nil {
+		log.Error(err, "unable to mourn friends")
+		return ctrl.Result{}, recordError(span, err)
 	}
 
 	// 🧃 Handle annotation-based feeding/petting
+	_, feedAnnot := pet.Annotations[cfg.Annotations.Feed]
+	_, petAnnot := pet.Annotations[cfg.Annotations.Pet]
 	if feedAnnot || petAnnot {
-		var (
-			foodDelta, petDelta int
-			err                 error
-		)
-
-		if feedAnnot {
-			foodDelta, err = strconv.Atoi(pet.Annotations["linuxfest.example.com/feed"])
-			if err != nil {
-				return ctrl.Result{}, err
-			}
-		}
-		if petAnnot {
-			petDelta, err = strconv.Atoi(pet.Annotations["linuxfest.example.com/pet"])
-			if err != nil {
-				return ctrl.Result{}, err
+		result, err := r.applyActions(ctx, &pet, cfg)
+		return result, recordError(span, err)
+	}
+
+	// 🚦 Tell the owners how many actions were dropped during the cooldown that ended
+	if err := r.reportRejections(ctx, &pet); err != nil {
+		log.Error(err, "unable to record rejected actions")
+		return ctrl.Result{}, recordError(span, err)
+	}
+
+	// ⏰ Otherwise leave it to the decay scheduler to decay the pet when it is due
+	key := client.ObjectKeyFromObject(&pet)
+	if isDead(&pet) {
+		log.V(1).Info("Pet is dead, skipping decay")
+		r.scheduler.forget(key)
+		return ctrl.Result{}, nil
+	}
+	r.scheduler.schedule(key, pet.Status.ModifiedTime.Add(pet.Spec.DecayInterval.Duration))
+	return ctrl.Result{}, nil
+}
+
+// initialize gives a newly created pet its starting food and love.
+func (r *PetReconciler) initialize(ctx context.Context, pet *linuxfestv2025.Pet, cfg *config.Configuration) (ctrl.Result, error) {
+	ctx, span := tracer.Start(ctx, "Initialize")
+	defer span.End()
+	log := log.FromContext(ctx)
+
+	for range 10 {
+		petCopy := pet.DeepCopy()
+		now := r.now()
+		state, events := engine.Init(kube.Pet(petCopy), cfg.Rules(petCopy.Spec.Species), now)
+		kube.Apply(petCopy, state, now)
+		petCopy.Status.ModifiedTime = v1.NewTime(now)
+		petCopy.Status.Initialized = true
+
+		// 💾 Save initial state
+		if err := r.Status().Update(ctx, petCopy); err != nil {
+			if errors.IsConflict(err) {
+				log.V(1).Info("Conflict while initializing pet, retrying")
+
+				// 🔁 Retry if needed
+				if err := r.Get(ctx, client.ObjectKeyFromObject(pet), pet); err != nil {
+					return ctrl.Result{}, recordError(span, client.IgnoreNotFound(err))
+				}
+				continue
+			} else if errors.IsNotFound(err) {
+				return ctrl.Result{}, nil
 			}
+
+			log.Error(err, "unable to update status")
+			return ctrl.Result{RequeueAfter: petCopy.Spec.DecayInterval.Duration}, recordError(span, err)
 		}
 
-		// 🧹 Remove annotations after applying them
-		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			if err := r.Get(ctx, client.ObjectKey{Name: pet.Name, Namespace: pet.Namespace}, &pet); err != nil {
-				return client.IgnoreNotFound(err)
-			}
-			cpy := pet.DeepCopy()
-			delete(cpy.Annotations, "linuxfest.example.com/feed")
-			delete(cpy.Annotations, "linuxfest.example.com/pet")
-			return r.Update(ctx, cpy)
-		})
+		log.Info("Initialized pet", "food", petCopy.Status.Food, "love", petCopy.Status.Love)
+		r.recordHistory(ctx, petCopy, "Initialized", cfg)
+		r.recordEvents(ctx, pet.Status, petCopy, events)
+
+		// 🕐 Schedule next decay
+		return ctrl.Result{RequeueAfter: petCopy.Spec.DecayInterval.Duration}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// applyActions consumes the feed and pet annotations and adds them to the pet's status.
+func (r *PetReconciler) applyActions(ctx context.Context, pet *linuxfestv2025.Pet, cfg *config.Configuration) (ctrl.Result, error) {
+	ctx, span := tracer.Start(ctx, "ApplyActions")
+	defer span.End()
+	log := log.FromContext(ctx)
+
+	_, feedAnnot := pet.Annotations[cfg.Annotations.Feed]
+	_, petAnnot := pet.Annotations[cfg.Annotations.Pet]
+
+	var (
+		foodDelta, petDelta int
+		err                 error
+	)
+
+	// ⚠️ Malformed annotations are dropped, retrying them would never succeed
+	if feedAnnot {
+		foodDelta, err = strconv.Atoi(pet.Annotations[cfg.Annotations.Feed])
 		if err != nil {
-			return ctrl.Result{}, err
+			log.Error(err, "invalid feed annotation", "value", pet.Annotations[cfg.Annotations.Feed])
+			r.event(ctx, pet, nil, corev1.EventTypeWarning, "InvalidAction",
+				fmt.Sprintf("⚠️ %s ignored the invalid feed annotation %q", pet.Spec.Nickname, pet.Annotations[cfg.Annotations.Feed]))
 		}
-
-		if foodDelta <= 0 && petDelta <= 0 {
-			return ctrl.Result{RequeueAfter: pet.Spec.DecayInterval.Duration}, nil
+	}
+	if petAnnot {
+		petDelta, err = strconv.Atoi(pet.Annotations[cfg.Annotations.Pet])
+		if err != nil {
+			log.Error(err, "invalid pet annotation", "value", pet.Annotations[cfg.Annotations.Pet])
+			r.event(ctx, pet, nil, corev1.EventTypeWarning, "InvalidAction",
+				fmt.Sprintf("⚠️ %s ignored the invalid pet annotation %q", pet.Spec.Nickname, pet.Annotations[cfg.Annotations.Pet]))
 		}
+	}
+	span.SetAttributes(attribute.Int("pet.food_delta", foodDelta), attribute.Int("pet.love_delta", petDelta))
 
-		// 💖 Update status fields with feed/pet deltas
-		err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			if err := r.Get(ctx, client.ObjectKey{Name: pet.Name, Namespace: pet.Namespace}, &pet); err != nil {
-				return client.IgnoreNotFound(err)
-			}
-
-			cpy := pet.DeepCopy()
-			cpy.Status.Food += foodDelta
-			if cpy.Status.Food > 100 {
-				cpy.Status.Food = 100
-			}
-			cpy.Status.FedTime = v1.Now()
+	// 🙋 The admission webhook stamps who asked for the actions
+	caretaker := r.requester(pet)
 
-			cpy.Status.Love += petDelta
-			if cpy.Status.Love > 100 {
-				cpy.Status.Love = 100
-			}
-			cpy.Status.PetTime = v1.Now()
+	// 🧹 Remove annotations after applying them
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, client.ObjectKey{Name: pet.Name, Namespace: pet.Namespace}, pet); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		cpy := pet.DeepCopy()
+		delete(cpy.Annotations, cfg.Annotations.Feed)
+		delete(cpy.Annotations, cfg.Annotations.Pet)
+		delete(cpy.Annotations, linuxfestv2025.RequesterAnnotation)
+		return r.Update(ctx, cpy)
+	})
+	if err != nil {
+		log.Error(err, "unable to remove action annotations")
+		return ctrl.Result{}, recordError(span, err)
+	}
 
-			return r.Status().Update(ctx, cpy)
-		})
+	if foodDelta <= 0 && petDelta <= 0 {
+		log.V(1).Info("Ignoring non-positive action", "foodDelta", foodDelta, "loveDelta", petDelta)
+		return ctrl.Result{RequeueAfter: pet.Spec.DecayInterval.Duration}, nil
+	}
+	if isDead(pet) {
+		log.V(1).Info("Ignoring action on a dead pet", "foodDelta", foodDelta, "loveDelta", petDelta)
+		return ctrl.Result{RequeueAfter: pet.Spec.DecayInterval.Duration}, nil
+	}
 
-		// 🔁 Schedule next decay
-		return ctrl.Result{RequeueAfter: pet.Spec.DecayInterval.Duration}, err
+	// 🚦 Drop actions beyond the pet's or the caretaker's rate limit
+	if delay, limit := r.limiter.reserve(r.now(), actionBuckets(pet, caretaker, cfg)...); delay > 0 {
+		result, err := r.rejectActions(ctx, pet, limit, delay)
+		return result, recordError(span, err)
 	}
 
-	// 🧓 Otherwise, apply decay to food and love over time
-	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		if err := r.Get(ctx, client.ObjectKey{Name: pet.Name, Namespace: pet.Namespace}, &pet); err != nil {
+	// 💖 Update status fields with feed/pet deltas
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, client.ObjectKey{Name: pet.Name, Namespace: pet.Namespace}, pet); err != nil {
 			return client.IgnoreNotFound(err)
 		}
 
 		cpy := pet.DeepCopy()
-		if cpy.Status.Food > cpy.Spec.FoodDecayRate {
-			cpy.Status.Food -= cpy.Spec.FoodDecayRate
-		} else {
-			cpy.Status.Food = 0
-		}
-		if cpy.Status.Love > cpy.Spec.LoveDecayRate {
-			cpy.Status.Love -= cpy.Spec.LoveDecayRate
-		} else {
-			cpy.Status.Love = 0
+		now := r.now()
+
+		// 🤢 The engine caps food and love and overfeeds pets fed beyond the cap
+		state, events := engine.Act(kube.Pet(cpy), cfg.Rules(cpy.Spec.Species), now, foodDelta, petDelta)
+		kube.Apply(cpy, state, now)
+		cpy.Status.FedTime = v1.NewTime(now)
+		cpy.Status.PetTime = v1.NewTime(now)
+
+		if err := r.Status().Update(ctx, cpy); err != nil {
+			return err
 		}
 
-		cpy.Status.ModifiedTime = v1.Now()
+		log.Info("Applied actions",
+			"foodDelta", foodDelta, "loveDelta", petDelta,
+			"food", cpy.Status.Food, "love", cpy.Status.Love)
 
-		if cpy.Status.Food == 0 {
-			r.Recorder.Event(cpy, corev1.EventTypeWarning, "Dead", fmt.Sprintf("☠️ %s died", cpy.Spec.Nickname))
-			// r.Recorder.Event(cpy, corev1.EventTypeNormal, "Fed", fmt.Sprintf("🐾 Pet food decayed by %d. New food level: %d", cpy.Spec.FoodDecayRate, cpy.Status.Food))
-		} else if cpy.Status.Love == 0 {
-			r.Recorder.Event(cpy, corev1.EventTypeWarning, "NeedLove", fmt.Sprintf("😢 %s Needs Love and Attention", cpy.Spec.Nickname))
-			// r.Recorder.Event(cpy, corev1.EventTypeNormal, "Fed", fmt.Sprintf("🐾 Pet food decayed by %d. New food level: %d", cpy.Spec.FoodDecayRate, cpy.Status.Food))
-		} else if cpy.Status.Food < 30 {
-			r.Recorder.Event(cpy, corev1.EventTypeWarning, "NeedFood", fmt.Sprintf("😭%s Needs Food", cpy.Spec.Nickname))
+		var causes []string
+		if foodDelta > 0 {
+			causes = append(causes, "Fed")
 		}
-		return r.Status().Update(ctx, cpy)
+		if cpy.Status.Overfeedings > pet.Status.Overfeedings {
+			causes = append(causes, "Overfed")
+		}
+		if petDelta > 0 {
+			causes = append(causes, "Petted")
+		}
+		r.recordEvents(ctx, pet.Status, cpy, events)
+		r.recordHistory(ctx, cpy, strings.Join(causes, ","), cfg)
+		return nil
 	})
+	if err != nil {
+		log.Error(err, "unable to apply actions")
+		return ctrl.Result{RequeueAfter: pet.Spec.DecayInterval.Duration}, recordError(span, err)
+	}
 
-	// 🔁 Requeue for next decay tick
-	return ctrl.Result{RequeueAfter: pet.Spec.DecayInterval.Duration}, err
+	// 🏅 Credit the caretaker on the leaderboard
+	r.recordCare(pet, caretaker, foodDelta > 0, petDelta > 0)
+
+	// 🔁 Schedule next decay
+	return ctrl.Result{RequeueAfter: pet.Spec.DecayInterval.Duration}, nil
+}
+
+// decay reduces the due pet's food and love by its decay rates, adds the love its
+// happy friends give it, ages it and warns when it needs care. It is called by the
+// decay scheduler and reads the pet from the cache, every interval the pet is
+// overdue is decayed and the status is written with a single patch that fails
+// instead of retrying when the pet changed meanwhile.
+func (r *PetReconciler) decay(ctx context.Context, key types.NamespacedName) error {
+	ctx, span := tracer.Start(ctx, "Decay", trace.WithAttributes(
+		attribute.String("pet.name", key.Name),
+		attribute.String("pet.namespace", key.Namespace),
+	))
+	defer span.End()
+
+	if !r.owns(key) {
+		r.scheduler.forget(key)
+		return nil
+	}
+
+	var pet linuxfestv2025.Pet
+	if err := r.Get(ctx, key, &pet); err != nil {
+		if errors.IsNotFound(err) {
+			r.scheduler.forget(key)
+			return nil
+		}
+		return recordError(span, err)
+	}
+
+	log := log.FromContext(ctx).WithValues("namespace", key.Namespace, "name", key.Name, "pet", pet.Spec.Nickname)
+	ctx = ctrl.LoggerInto(ctx, log)
+	cfg := r.config()
+
+	// 🛑 Reconciles take over restoring, new, acted on and dead pets and schedule them again
+	_, restoring := pet.Annotations[linuxfestv2025.RestoringAnnotation]
+	_, feedAnnot := pet.Annotations[cfg.Annotations.Feed]
+	_, petAnnot := pet.Annotations[cfg.Annotations.Pet]
+	if restoring || !pet.Status.Initialized || feedAnnot || petAnnot || isDead(&pet) {
+		return nil
+	}
+
+	// ⏳ The pet changed since it was scheduled
+	now := r.now()
+	due := pet.Status.ModifiedTime.Add(pet.Spec.DecayInterval.Duration)
+	if now.Before(due) {
+		r.scheduler.schedule(key, due)
+		return nil
+	}
+
+	friends, err := r.friends(ctx, &pet)
+	if err != nil {
+		return recordError(span, err)
+	}
+
+	cpy := pet.DeepCopy()
+	rules := cfg.Rules(cpy.Spec.Species)
+	bonus := friendshipBonus(cpy, friends, rules)
+	intervals, end := overdueIntervals(&pet, now)
+	span.SetAttributes(
+		attribute.Int("pet.food_decay_rate", pet.Spec.FoodDecayRate),
+		attribute.Int("pet.love_decay_rate", pet.Spec.LoveDecayRate),
+		attribute.Int("pet.decay_intervals", intervals),
+	)
+
+	// 📦 Fold every interval the pet is overdue into a single write
+	// 🚨 Only changes in stage, hunger, loneliness, sickness or death are worth an event
+	var events []engine.Event
+	for i := intervals - 1; i >= 0 && !isDead(cpy); i-- {
+		at := end.Add(-time.Duration(i) * pet.Spec.DecayInterval.Duration)
+		state, decayed := engine.Decay(kube.Pet(cpy), rules, at, bonus)
+		kube.Apply(cpy, state, at)
+		events = append(events, decayed...)
+	}
+	cpy.Status.ModifiedTime = v1.NewTime(end)
+
+	awarded := updateSurvival(cpy, now)
+	patch := client.MergeFromWithOptions(&pet, client.MergeFromWithOptimisticLock{})
+	if err := r.Status().Patch(ctx, cpy, patch); err != nil {
+		switch {
+		case errors.IsNotFound(err):
+			r.scheduler.forget(key)
+			return nil
+		case errors.IsConflict(err):
+			decayWrites.WithLabelValues("conflict").Inc()
+			log.V(1).Info("Conflict while decaying pet, retrying")
+		default:
+			decayWrites.WithLabelValues("error").Inc()
+			log.Error(err, "unable to decay pet")
+		}
+		return recordError(span, err)
+	}
+	decayWrites.WithLabelValues("decayed").Inc()
+	r.lags.observe(now, key.Namespace, now.Sub(due), pet.Spec.DecayInterval.Duration)
+
+	log.V(1).Info("Decayed pet", "food", cpy.Status.Food, "love", cpy.Status.Love, "health", cpy.Status.Health,
+		"friendshipBonus", bonus, "stage", cpy.Status.Stage, "careScore", cpy.Status.CareScore())
+	r.recordEvents(ctx, pet.Status, cpy, events)
+	r.recordPetAchievements(ctx, pet.Status, cpy, awarded)
+	r.recordHistory(ctx, cpy, "Decay", cfg)
+
+	// 🔁 Schedule the next decay tick
+	if !isDead(cpy) {
+		r.scheduler.schedule(key, end.Add(cpy.Spec.DecayInterval.Duration))
+	}
+	return nil
+}
+
+// maxFoldedIntervals bounds the intervals decayed in one write, a pet overdue
+// for longer loses the decay of the earlier ones.
+const maxFoldedIntervals = 100
+
+// overdueIntervals returns how many decay intervals of pet ended by now, at
+// most maxFoldedIntervals, and when the last of them ended.
+func overdueIntervals(pet *linuxfestv2025.Pet, now time.Time) (int, time.Time) {
+	interval := pet.Spec.DecayInterval.Duration
+	if interval <= 0 || pet.Status.ModifiedTime.IsZero() {
+		return 1, now
+	}
+
+	intervals := int(now.Sub(pet.Status.ModifiedTime.Time) / interval)
+	end := pet.Status.ModifiedTime.Add(time.Duration(intervals) * interval)
+	return min(max(intervals, 1), maxFoldedIntervals), end
+}
+
+// config returns the current configuration.
+func (r *PetReconciler) config() *config.Configuration {
+	if r.Config == nil {
+		return config.Default()
+	}
+	return r.Config.Get()
+}
+
+// now returns the current time of the reconciler's clock.
+// requester returns who asked for the actions on pet, or an empty string when
+// the requester annotation can not be trusted.
+func (r *PetReconciler) requester(pet *linuxfestv2025.Pet) string {
+	if !r.TrustRequester {
+		return ""
+	}
+	return pet.Annotations[linuxfestv2025.RequesterAnnotation]
+}
+
+func (r *PetReconciler) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
+
+// event records an event for pet with annotations and forwards warnings to the notification sinks.
+func (r *PetReconciler) event(ctx context.Context, pet *linuxfestv2025.Pet, annotations map[string]string, eventType, reason, message string) {
+	r.Recorder.AnnotatedEventf(pet, annotations, eventType, reason, "%s", message)
+	if eventType == corev1.EventTypeWarning && r.Notifier != nil {
+		r.Notifier.Notify(ctx, pet, reason, message)
+	}
+}
+
+// recordHistory appends the current state of pet to its history when history is enabled.
+func (r *PetReconciler) recordHistory(ctx context.Context, pet *linuxfestv2025.Pet, cause string, cfg *config.Configuration) {
+	if r.History == nil || !cfg.History.Enabled {
+		return
+	}
+
+	sample := history.Sample{
+		Time:   v1.NewTime(r.now()),
+		Food:   pet.Status.Food,
+		Love:   pet.Status.Love,
+		Health: pet.Status.Health,
+		Cause:  cause,
+	}
+	if err := r.History.Record(ctx, pet, sample, cfg.History.Size); err != nil {
+		// 📉 A missing sample is not worth failing the reconcile for
+		log.FromContext(ctx).Error(err, "unable to record history", "cause", cause)
+	}
+}
+
+// recordError marks span as failed when err is not nil and returns err unchanged.
+func recordError(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *PetReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	r.Recorder = mgr.GetEventRecorderFor("pet-controller")
+	// 👻 Work out every change without making it
+	if r.DryRun {
+		r.Client = NewDryRunClient(r.Client)
+		r.Recorder = newDryRunRecorder()
+		r.Notifier = nil
+		if r.History != nil {
+			r.History = &history.Recorder{Client: NewDryRunClient(r.History.Client), Scheme: r.History.Scheme}
+		}
+	}
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor("pet-controller")
+	}
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&linuxfestv2025.Pet{}).
-		Named("pet").
+	// ⏰ One queue decays every pet instead of a requeue per pet
+	r.scheduler = newDecayScheduler(r.now, r.Decay, r.decay)
+	if err := mgr.Add(r.scheduler); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &linuxfestv2025.Pet{}, friendsIndex, indexFriends); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &linuxfestv2025.PetTreatment{}, treatmentPetIndex, indexTreatmentPet); err != nil {
+		return err
+	}
+
+	// 🤫 Status writes of the decay scheduler don't need a reconcile, pets are
+	// only reconciled when they are created, deleted, changed or acted on
+	changed := predicate.Or(
+		predicate.GenerationChangedPredicate{},
+		predicate.AnnotationChangedPredicate{},
+		predicate.LabelChangedPredicate{},
+	)
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&linuxfestv2025.Pet{}, builder.WithPredicates(changed)).
+		// 🐾 Let pets notice changes to the pets that count them as friends and their deaths
+		Watches(&linuxfestv2025.Pet{}, handler.EnqueueRequestsFromMapFunc(r.friendsOf),
+			builder.WithPredicates(predicate.Or[client.Object](changed, died))).
+		// 💊 Apply treatments as soon as they are created
+		Watches(&linuxfestv2025.PetTreatment{}, handler.EnqueueRequestsFromMapFunc(treatedPet))
+	if r.Shards != nil {
+		// 🧩 Reconcile the pets of shards this replica takes over
+		b = b.WatchesRawSource(source.Channel(r.Shards.Events(), &handler.EnqueueRequestForObject{}))
+	}
+	return b.Named("pet").
+		WithOptions(r.Options.controllerOptions()).
 		Complete(r)
 }
+
+// died passes updates of pets that died or were revived, so their friends can mourn them.
+var died = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		old, oldOK := e.ObjectOld.(*linuxfestv2025.Pet)
+		pet, ok := e.ObjectNew.(*linuxfestv2025.Pet)
+		return oldOK && ok && isDead(old) != isDead(pet)
+	},
+}
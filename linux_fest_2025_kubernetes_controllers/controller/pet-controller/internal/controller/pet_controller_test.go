@@ -18,67 +18,316 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/types"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
 )
 
+const (
+	timeout  = 10 * time.Second
+	interval = 100 * time.Millisecond
+)
+
+// eventsOf returns the events recorded so far whose message mentions nickname.
+func eventsOf(nickname string) func() []string {
+	return func() []string {
+		eventsMu.Lock()
+		defer eventsMu.Unlock()
+
+		var matching []string
+		for _, event := range events {
+			if strings.Contains(event, " "+nickname+" ") {
+				matching = append(matching, event)
+			}
+		}
+		return matching
+	}
+}
+
+// countEvents returns how many reason events were recorded for nickname so far.
+func countEvents(reason, nickname string) func() int {
+	return func() int {
+		eventsMu.Lock()
+		defer eventsMu.Unlock()
+
+		count := 0
+		for _, event := range events {
+			if strings.Contains(event, " "+reason+" ") && strings.Contains(event, nickname) {
+				count++
+			}
+		}
+		return count
+	}
+}
+
 var _ = Describe("Pet Controller", func() {
-	Context("When reconciling a resource", func() {
-		const resourceName = "test-resource"
+	ctx := context.Background()
+
+	// fetch returns a function getting the latest version of pet.
+	fetch := func(pet *linuxfestv2025.Pet) func() (*linuxfestv2025.Pet, error) {
+		return func() (*linuxfestv2025.Pet, error) {
+			var latest linuxfestv2025.Pet
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(pet), &latest)
+			return &latest, err
+		}
+	}
 
-		ctx := context.Background()
+	// newPet creates a pet decaying every second and waits for it to be initialized.
+	newPet := func(name string, spec linuxfestv2025.PetSpec) *linuxfestv2025.Pet {
+		GinkgoHelper()
 
-		typeNamespacedName := types.NamespacedName{
-			Name:      resourceName,
-			Namespace: "default", // TODO(user):Modify as needed
+		spec.DecayInterval = metav1.Duration{Duration: time.Second}
+		pet := &linuxfestv2025.Pet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       spec,
 		}
-		pet := &linuxfestv2025.Pet{}
-
-		BeforeEach(func() {
-			By("creating the custom resource for the Kind Pet")
-			err := k8sClient.Get(ctx, typeNamespacedName, pet)
-			if err != nil && errors.IsNotFound(err) {
-				resource := &linuxfestv2025.Pet{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      resourceName,
-						Namespace: "default",
-					},
-					// TODO(user): Specify other spec details if needed.
-				}
-				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		Expect(k8sClient.Create(ctx, pet)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, pet))).To(Succeed())
+		})
+
+		Eventually(fetch(pet)).WithTimeout(timeout).WithPolling(interval).
+			Should(HaveField("Status.Initialized", BeTrue()))
+		return pet
+	}
+
+	// update applies mutate to the latest version of pet, or of its status.
+	update := func(pet *linuxfestv2025.Pet, status bool, mutate func(*linuxfestv2025.Pet)) {
+		GinkgoHelper()
+
+		Expect(retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			latest, err := fetch(pet)()
+			if err != nil {
+				return err
+			}
+			mutate(latest)
+			if status {
+				return k8sClient.Status().Update(ctx, latest)
 			}
+			return k8sClient.Update(ctx, latest)
+		})).To(Succeed())
+	}
+
+	annotate := func(pet *linuxfestv2025.Pet, annotations map[string]string) {
+		GinkgoHelper()
+		update(pet, false, func(latest *linuxfestv2025.Pet) {
+			if latest.Annotations == nil {
+				latest.Annotations = map[string]string{}
+			}
+			maps.Copy(latest.Annotations, annotations)
 		})
+	}
+
+	// tick steps the clock by one decay interval and waits for pet to decay.
+	tick := func(pet *linuxfestv2025.Pet) *linuxfestv2025.Pet {
+		GinkgoHelper()
+
+		before, err := fetch(pet)()
+		Expect(err).NotTo(HaveOccurred())
+		fakeClock.Step(pet.Spec.DecayInterval.Duration)
+
+		Eventually(fetch(pet)).WithTimeout(timeout).WithPolling(interval).
+			Should(HaveField("Status.Ticks", before.Status.Ticks+1))
+		after, err := fetch(pet)()
+		Expect(err).NotTo(HaveOccurred())
+		return after
+	}
+
+	It("should initialize new pets with full food, love and health", func() {
+		pet := newPet("biscuit", linuxfestv2025.PetSpec{Nickname: "Biscuit"})
+
+		latest, err := fetch(pet)()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(latest.Status.Food).To(Equal(100))
+		Expect(latest.Status.Love).To(Equal(100))
+		Expect(latest.Status.Health).To(Equal(100))
+		Expect(latest.Status.Stage).To(Equal(linuxfestv2025.PetStageBaby))
+		Expect(latest.Status.ModifiedTime.Time).To(BeTemporally("==", fakeClock.Now()))
+		Expect(meta.IsStatusConditionFalse(latest.Status.Conditions, linuxfestv2025.PetConditionHungry)).To(BeTrue())
+
+		Eventually(eventsOf("Biscuit")).WithTimeout(timeout).
+			Should(ContainElement(HavePrefix("Normal Initialized ")))
+	})
 
-		AfterEach(func() {
-			// TODO(user): Cleanup logic after each test, like removing the resource instance.
-			resource := &linuxfestv2025.Pet{}
-			err := k8sClient.Get(ctx, typeNamespacedName, resource)
-			Expect(err).NotTo(HaveOccurred())
+	It("should decay food and love by their rates once every interval", func() {
+		pet := newPet("pickle", linuxfestv2025.PetSpec{Nickname: "Pickle", FoodDecayRate: 3, LoveDecayRate: 2})
 
-			By("Cleanup the specific resource instance Pet")
-			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		latest := tick(pet)
+		Expect(latest.Status.Food).To(Equal(97))
+		Expect(latest.Status.Love).To(Equal(98))
+
+		latest = tick(pet)
+		Expect(latest.Status.Food).To(Equal(94))
+		Expect(latest.Status.Love).To(Equal(96))
+
+		By("not decaying while the clock stands still")
+		Consistently(fetch(pet)).WithTimeout(2 * time.Second).WithPolling(interval).
+			Should(HaveField("Status.Ticks", latest.Status.Ticks))
+	})
+
+	It("should consume feed and pet annotations", func() {
+		pet := newPet("noodle", linuxfestv2025.PetSpec{Nickname: "Noodle"})
+		update(pet, true, func(latest *linuxfestv2025.Pet) {
+			latest.Status.Food, latest.Status.Love = 40, 30
 		})
-		It("should successfully reconcile the resource", func() {
-			By("Reconciling the created resource")
-			controllerReconciler := &PetReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
-			}
 
-			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
-				NamespacedName: typeNamespacedName,
-			})
-			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+		annotate(pet, map[string]string{
+			"linuxfest.example.com/feed": "25",
+			"linuxfest.example.com/pet":  "15",
+		})
+
+		Eventually(fetch(pet)).WithTimeout(timeout).WithPolling(interval).Should(And(
+			HaveField("Annotations", Not(HaveKey("linuxfest.example.com/feed"))),
+			HaveField("Annotations", Not(HaveKey("linuxfest.example.com/pet"))),
+			HaveField("Status.Food", 65),
+			HaveField("Status.Love", 45),
+		))
+		Eventually(eventsOf("Noodle")).WithTimeout(timeout).Should(ContainElements(
+			HavePrefix("Normal Fed "),
+			HavePrefix("Normal Petted "),
+		))
+	})
+
+	It("should clamp food and love at their maximum", func() {
+		pet := newPet("waffle", linuxfestv2025.PetSpec{Nickname: "Waffle"})
+		update(pet, true, func(latest *linuxfestv2025.Pet) {
+			latest.Status.Food, latest.Status.Love = 90, 95
+		})
+
+		annotate(pet, map[string]string{
+			"linuxfest.example.com/feed": "50",
+			"linuxfest.example.com/pet":  "50",
+		})
+
+		Eventually(fetch(pet)).WithTimeout(timeout).WithPolling(interval).Should(And(
+			HaveField("Annotations", Not(HaveKey("linuxfest.example.com/feed"))),
+			HaveField("Status.Food", 100),
+			HaveField("Status.Love", 100),
+		))
+
+		latest, err := fetch(pet)()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(meta.IsStatusConditionTrue(latest.Status.Conditions, linuxfestv2025.PetConditionOverfed)).To(BeTrue())
+	})
+
+	It("should drop malformed annotations", func() {
+		pet := newPet("mochi", linuxfestv2025.PetSpec{Nickname: "Mochi"})
+		update(pet, true, func(latest *linuxfestv2025.Pet) {
+			latest.Status.Food = 50
+		})
+
+		annotate(pet, map[string]string{"linuxfest.example.com/feed": "lots"})
+
+		Eventually(fetch(pet)).WithTimeout(timeout).WithPolling(interval).
+			Should(HaveField("Annotations", Not(HaveKey("linuxfest.example.com/feed"))))
+		Expect(fetch(pet)()).To(HaveField("Status.Food", 50))
+		Eventually(eventsOf("Mochi")).WithTimeout(timeout).
+			Should(ContainElement(HavePrefix("Warning InvalidAction ")))
+	})
+
+	It("should let neglected pets die and ignore them afterwards", func() {
+		pet := newPet("pudding", linuxfestv2025.PetSpec{Nickname: "Pudding"})
+		update(pet, true, func(latest *linuxfestv2025.Pet) {
+			latest.Status.Food, latest.Status.Love = 0, 0
+			latest.Status.Health = 10
+			latest.Status.NeglectedTicks = 3
+		})
+
+		latest := tick(pet)
+		Expect(latest.Status.Health).To(BeZero())
+		Expect(meta.IsStatusConditionTrue(latest.Status.Conditions, linuxfestv2025.PetConditionDead)).To(BeTrue())
+		Eventually(eventsOf("Pudding")).WithTimeout(timeout).
+			Should(ContainElement(HavePrefix("Warning Dead ")))
+
+		By("not decaying or eating once dead")
+		fakeClock.Step(pet.Spec.DecayInterval.Duration)
+		annotate(pet, map[string]string{"linuxfest.example.com/feed": "50"})
+
+		Eventually(fetch(pet)).WithTimeout(timeout).WithPolling(interval).
+			Should(HaveField("Annotations", Not(HaveKey("linuxfest.example.com/feed"))))
+		Consistently(fetch(pet)).WithTimeout(2 * time.Second).WithPolling(interval).Should(And(
+			HaveField("Status.Ticks", latest.Status.Ticks),
+			HaveField("Status.Food", 0),
+		))
+	})
+
+	It("should report a condition once until it clears", func() {
+		pet := newPet("churro", linuxfestv2025.PetSpec{Nickname: "Churro", FoodDecayRate: 5})
+		update(pet, true, func(latest *linuxfestv2025.Pet) {
+			latest.Status.Food = 33
 		})
+
+		tick(pet)
+		Eventually(countEvents("NeedFood", "Churro")).WithTimeout(timeout).Should(Equal(1))
+
+		By("staying hungry for another interval")
+		latest := tick(pet)
+		Expect(meta.IsStatusConditionTrue(latest.Status.Conditions, linuxfestv2025.PetConditionHungry)).To(BeTrue())
+		Consistently(countEvents("NeedFood", "Churro")).WithTimeout(2 * time.Second).Should(Equal(1))
+
+		By("being fed")
+		annotate(pet, map[string]string{"linuxfest.example.com/feed": "50"})
+		Eventually(countEvents("NoLongerHungry", "Churro")).WithTimeout(timeout).Should(Equal(1))
+
+		By("getting hungry again")
+		update(pet, true, func(latest *linuxfestv2025.Pet) {
+			latest.Status.Food = 33
+		})
+		tick(pet)
+		Eventually(countEvents("NeedFood", "Churro")).WithTimeout(timeout).Should(Equal(2))
+		Consistently(countEvents("NoLongerHungry", "Churro")).WithTimeout(time.Second).Should(Equal(1))
+	})
+
+	It("should not lose changes of concurrent writers", func() {
+		const writers = 5
+
+		pet := newPet("tofu", linuxfestv2025.PetSpec{Nickname: "Tofu"})
+		update(pet, true, func(latest *linuxfestv2025.Pet) {
+			latest.Status.Food = 50
+		})
+
+		var wg sync.WaitGroup
+		for i := range writers {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				update(pet, false, func(latest *linuxfestv2025.Pet) {
+					if latest.Labels == nil {
+						latest.Labels = map[string]string{}
+					}
+					if latest.Annotations == nil {
+						latest.Annotations = map[string]string{}
+					}
+					latest.Labels[fmt.Sprintf("writer-%d", i)] = "true"
+					latest.Annotations["linuxfest.example.com/feed"] = "5"
+				})
+			}()
+		}
+		wg.Wait()
+
+		Eventually(fetch(pet)).WithTimeout(timeout).WithPolling(interval).Should(And(
+			HaveField("Annotations", Not(HaveKey("linuxfest.example.com/feed"))),
+			HaveField("Status.Food", And(BeNumerically(">", 50), BeNumerically("<=", 50+5*writers))),
+		))
+
+		latest, err := fetch(pet)()
+		Expect(err).NotTo(HaveOccurred())
+		for i := range writers {
+			Expect(latest.Labels).To(HaveKeyWithValue(fmt.Sprintf("writer-%d", i), "true"))
+		}
 	})
 })
@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// CacheSyncedChecker is ready once the informers of c have synced, so the
+// manager isn't ready while its reconciles still see an empty cache.
+func CacheSyncedChecker(c cache.Cache, timeout time.Duration) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		if !c.WaitForCacheSync(ctx) {
+			return errors.New("informers have not synced yet")
+		}
+		return nil
+	}
+}
+
+// DecayLagChecker fails when the earliest pet that is due to decay has been
+// waiting for longer than threshold, so a wedged controller gets restarted.
+// SetupWithManager must have been called first.
+func (r *PetReconciler) DecayLagChecker(threshold time.Duration) healthz.Checker {
+	return func(*http.Request) error {
+		if lag := r.scheduler.lag(r.now()); lag > threshold {
+			return fmt.Errorf("a pet is overdue to decay by %s, more than %s", lag.Round(time.Second), threshold)
+		}
+		return nil
+	}
+}
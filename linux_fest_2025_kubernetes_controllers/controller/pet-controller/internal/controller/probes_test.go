@@ -0,0 +1,58 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+)
+
+var _ = Describe("Probes", func() {
+	It("should be ready once the informers synced", func() {
+		informers := &informertest.FakeInformers{Synced: ptr.To(false)}
+		check := CacheSyncedChecker(informers, 10*time.Millisecond)
+
+		Expect(check(httptest.NewRequest("GET", "/readyz", nil))).To(MatchError(ContainSubstring("not synced")))
+
+		informers.Synced = ptr.To(true)
+		Expect(check(httptest.NewRequest("GET", "/readyz", nil))).To(Succeed())
+	})
+
+	It("should fail once a pet is overdue to decay for too long", func() {
+		clock := clocktesting.NewFakeClock(time.Date(2025, 4, 26, 0, 0, 0, 0, time.UTC))
+		r := &PetReconciler{Clock: clock}
+		r.scheduler = newDecayScheduler(r.now, DecayOptions{}, nil)
+		check := r.DecayLagChecker(time.Minute)
+		req := httptest.NewRequest("GET", "/healthz", nil)
+
+		Expect(check(req)).To(Succeed())
+
+		r.scheduler.schedule(types.NamespacedName{Namespace: "default", Name: "rex"}, clock.Now())
+		clock.Step(time.Minute)
+		Expect(check(req)).To(Succeed())
+
+		clock.Step(time.Second)
+		Expect(check(req)).To(MatchError(ContainSubstring("overdue to decay by 1m1s")))
+	})
+})
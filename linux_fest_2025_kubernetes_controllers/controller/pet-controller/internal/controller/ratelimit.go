@@ -0,0 +1,313 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/config"
+)
+
+// bucket is a token bucket an action takes a token from.
+type bucket struct {
+	// kind is what the bucket limits, a pet or a caretaker.
+	kind string
+
+	// subject is the name of the limited pet or caretaker.
+	subject string
+
+	key string
+	cfg config.BucketConfig
+}
+
+// limiterSweepInterval is how often the actionLimiter drops buckets that refilled.
+const limiterSweepInterval = time.Minute
+
+// actionLimiter holds the token buckets of feed and pet actions. Its zero value is ready to use.
+//
+// The buckets live in the memory of a replica. With sharding a pet is only
+// reconciled by the owner of its shard, so its bucket is shared by all its
+// actions, but a caretaker caring for pets in shards held by different replicas
+// draws from one bucket per replica. A bucket starts full again when its shard moves.
+type actionLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*rate.Limiter
+	swept   time.Time
+}
+
+// reserve takes a token from every bucket, or from none of them when one is
+// empty. It returns how long until the empty bucket has a token again and the bucket.
+func (l *actionLimiter) reserve(now time.Time, buckets ...bucket) (time.Duration, bucket) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	reservations := make([]*rate.Reservation, 0, len(buckets))
+	for _, b := range buckets {
+		reservation := l.limiter(now, b).ReserveN(now, 1)
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			reservation.CancelAt(now)
+			for _, reserved := range reservations {
+				reserved.CancelAt(now)
+			}
+			return delay, b
+		}
+		reservations = append(reservations, reservation)
+	}
+	return 0, bucket{}
+}
+
+// limiter returns the limiter of b, updated to its current configuration.
+func (l *actionLimiter) limiter(now time.Time, b bucket) *rate.Limiter {
+	limit := rate.Inf
+	if b.cfg.Interval.Duration > 0 {
+		limit = rate.Every(b.cfg.Interval.Duration)
+	}
+
+	if l.buckets == nil {
+		l.buckets = map[string]*rate.Limiter{}
+	}
+	limiter, ok := l.buckets[b.key]
+	if !ok {
+		limiter = rate.NewLimiter(limit, b.cfg.Burst)
+		l.buckets[b.key] = limiter
+	}
+	if limiter.Limit() != limit {
+		limiter.SetLimitAt(now, limit)
+	}
+	if limiter.Burst() != b.cfg.Burst {
+		limiter.SetBurstAt(now, b.cfg.Burst)
+	}
+	return limiter
+}
+
+// sweep drops the buckets that refilled since they were last used, at most once
+// every limiterSweepInterval. A full bucket is no different from a new one, so
+// idle pets and caretakers do not keep their buckets around forever.
+func (l *actionLimiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < limiterSweepInterval {
+		return
+	}
+	l.swept = now
+
+	for key, limiter := range l.buckets {
+		if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// forget drops the bucket with key.
+func (l *actionLimiter) forget(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.buckets, key)
+}
+
+// anonymousCaretaker names the caretakers of a namespace nobody knows, they share one bucket.
+const anonymousCaretaker = "anonymous"
+
+// actionBuckets returns the buckets an action by caretaker on pet takes a token from.
+func actionBuckets(pet *linuxfestv2025.Pet, caretaker string, cfg *config.Configuration) []bucket {
+	user := bucket{
+		kind:    "caretaker",
+		subject: caretaker,
+		key:     "user/" + pet.Namespace + "/" + caretaker,
+		cfg:     cfg.UserBucket(pet.Namespace),
+	}
+	// 🙈 Without the admission webhook nobody knows who the caretaker is, so
+	// every caretaker of the namespace draws from the same bucket
+	if caretaker == "" {
+		user.subject = anonymousCaretaker
+		user.key = "users/" + pet.Namespace
+	}
+
+	return []bucket{{
+		kind:    "pet",
+		subject: pet.Spec.Nickname,
+		key:     "pet/" + client.ObjectKeyFromObject(pet).String(),
+		cfg:     cfg.PetBucket(pet.Namespace, pet.Spec.Species),
+	}, user}
+}
+
+// rejections counts the rejected actions of pets, so a pet flooded with actions
+// has its status written and an event recorded at most once per cooldown. Its
+// zero value is ready to use.
+type rejections struct {
+	mu   sync.Mutex
+	pets map[string]rejected
+}
+
+// rejected is what a pet was told about its rejected actions.
+type rejected struct {
+	// count is the number of rejected actions not written to the status yet.
+	count int
+
+	// until is the end of the cooldown last written to the status.
+	until time.Time
+}
+
+// reject counts a rejected action of the pet with key at now. When the pet is
+// not cooling down it starts a cooldown until then and returns the actions to
+// write, otherwise it keeps counting and returns zero.
+func (t *rejections) reject(key string, now, until time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.pets == nil {
+		t.pets = map[string]rejected{}
+	}
+	pet := t.pets[key]
+	pet.count++
+	if now.Before(pet.until) {
+		t.pets[key] = pet
+		return 0
+	}
+
+	count := pet.count
+	t.pets[key] = rejected{until: until}
+	return count
+}
+
+// due returns the actions of the pet with key still to be written once its
+// cooldown ended at now, and forgets the pet.
+func (t *rejections) due(key string, now time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pet, ok := t.pets[key]
+	if !ok || now.Before(pet.until) {
+		return 0
+	}
+	delete(t.pets, key)
+	return pet.count
+}
+
+// cooldown returns how long the pet with key keeps cooling down after now.
+func (t *rejections) cooldown(key string, now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return max(t.pets[key].until.Sub(now), 0)
+}
+
+// giveBack counts actions of the pet with key that could not be written again.
+func (t *rejections) giveBack(key string, count int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.pets == nil {
+		t.pets = map[string]rejected{}
+	}
+	pet := t.pets[key]
+	pet.count += count
+	t.pets[key] = pet
+}
+
+// forget drops the pet with key.
+func (t *rejections) forget(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.pets, key)
+}
+
+// rejectActions reports actions that were dropped because limit was empty for
+// delay. Actions dropped while the pet cools down are only counted, they are
+// written by reportRejections once the cooldown ends.
+func (r *PetReconciler) rejectActions(ctx context.Context, pet *linuxfestv2025.Pet, limit bucket, delay time.Duration) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Rate limited actions", "limit", limit.kind, "subject", limit.subject, "delay", delay)
+	rateLimitedActions.WithLabelValues(pet.Namespace, limit.kind).Inc()
+
+	key := client.ObjectKeyFromObject(pet).String()
+	now := r.now()
+	until := ceilSecond(now.Add(delay))
+	count := r.rejections.reject(key, now, until)
+	if count == 0 {
+		// ⏳ Come back when the cooldown ends to write what was counted meanwhile
+		return ctrl.Result{RequeueAfter: r.rejections.cooldown(key, now)}, nil
+	}
+
+	err := r.writeRejections(ctx, pet, count, until, fmt.Sprintf("🚦 %s ignored a request, %s %s is over its rate limit, try again in %s",
+		pet.Spec.Nickname, limit.kind, limit.subject, delay.Round(time.Second)))
+	if err != nil {
+		r.rejections.giveBack(key, count)
+		log.Error(err, "unable to record rejected actions")
+		return ctrl.Result{RequeueAfter: pet.Spec.DecayInterval.Duration}, err
+	}
+	return ctrl.Result{RequeueAfter: pet.Spec.DecayInterval.Duration}, nil
+}
+
+// reportRejections writes the actions of pet rejected during a cooldown that ended.
+func (r *PetReconciler) reportRejections(ctx context.Context, pet *linuxfestv2025.Pet) error {
+	key := client.ObjectKeyFromObject(pet).String()
+	count := r.rejections.due(key, r.now())
+	if count == 0 {
+		return nil
+	}
+
+	err := r.writeRejections(ctx, pet, count, pet.Status.CooldownUntil.Time,
+		fmt.Sprintf("🚦 %s ignored %d more requests while it was over its rate limit", pet.Spec.Nickname, count))
+	if err != nil {
+		r.rejections.giveBack(key, count)
+	}
+	return err
+}
+
+// writeRejections adds count rejected actions to the status of pet, sets its
+// cooldown to until and records message.
+func (r *PetReconciler) writeRejections(ctx context.Context, pet *linuxfestv2025.Pet, count int, until time.Time, message string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(pet), pet); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		cpy := pet.DeepCopy()
+		cpy.Status.RejectedActions += count
+		cpy.Status.CooldownUntil = v1.NewTime(until)
+		if err := r.Status().Update(ctx, cpy); err != nil {
+			return err
+		}
+
+		r.event(ctx, cpy, nil, corev1.EventTypeNormal, "RateLimited", message)
+		return nil
+	})
+}
+
+// ceilSecond rounds t up to a whole second, the precision of times stored in a
+// status, so a cooldown is never reported to end before it does.
+func ceilSecond(t time.Time) time.Time {
+	if truncated := t.Truncate(time.Second); truncated.Before(t) {
+		return truncated.Add(time.Second)
+	}
+	return t
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/config"
+)
+
+var _ = Describe("actionLimiter", func() {
+	now := time.Now()
+
+	newBucket := func(key string, burst int) bucket {
+		return bucket{kind: key, key: key, cfg: config.BucketConfig{
+			Interval: metav1.Duration{Duration: 10 * time.Second},
+			Burst:    burst,
+		}}
+	}
+
+	It("should allow a burst and then report the cooldown", func() {
+		var limiter actionLimiter
+		pet := newBucket("pet", 2)
+
+		Expect(limiter.reserve(now, pet)).To(BeZero())
+		Expect(limiter.reserve(now, pet)).To(BeZero())
+
+		delay, limit := limiter.reserve(now, pet)
+		Expect(delay).To(Equal(10 * time.Second))
+		Expect(limit.kind).To(Equal("pet"))
+
+		delay, _ = limiter.reserve(now.Add(10*time.Second), pet)
+		Expect(delay).To(BeZero())
+	})
+
+	It("should not take tokens when another bucket is empty", func() {
+		var limiter actionLimiter
+		pet, caretaker := newBucket("pet", 2), newBucket("caretaker", 1)
+
+		Expect(limiter.reserve(now, pet, caretaker)).To(BeZero())
+		delay, limit := limiter.reserve(now, pet, caretaker)
+		Expect(delay).To(BeNumerically(">", 0))
+		Expect(limit.kind).To(Equal("caretaker"))
+
+		// 🪙 the pet bucket kept the token of the rejected action
+		Expect(limiter.reserve(now, pet)).To(BeZero())
+	})
+
+	It("should not limit buckets without an interval", func() {
+		var limiter actionLimiter
+		unlimited := bucket{kind: "pet", key: "pet", cfg: config.BucketConfig{Burst: 1}}
+
+		for range 10 {
+			Expect(limiter.reserve(now, unlimited)).To(BeZero())
+		}
+	})
+
+	It("should drop buckets once they refilled", func() {
+		var limiter actionLimiter
+		pet := newBucket("pet", 2)
+		pet.cfg.Interval.Duration = 2 * limiterSweepInterval
+
+		limiter.reserve(now, pet)
+		limiter.reserve(now, pet)
+		Expect(limiter.buckets).To(HaveKey("pet"))
+
+		By("keeping buckets that are still refilling")
+		limiter.reserve(now.Add(limiterSweepInterval), newBucket("other", 1))
+		Expect(limiter.buckets).To(HaveKey("pet"))
+
+		By("dropping them once they are full again")
+		limiter.reserve(now.Add(4*limiterSweepInterval), newBucket("other", 1))
+		Expect(limiter.buckets).NotTo(HaveKey("pet"))
+	})
+})
+
+var _ = Describe("actionBuckets", func() {
+	cfg := config.Default()
+	pet := func(name string) *linuxfestv2025.Pet {
+		return &linuxfestv2025.Pet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	}
+
+	It("should give every caretaker its own bucket", func() {
+		alice, bob := actionBuckets(pet("rex"), "alice", cfg), actionBuckets(pet("rex"), "bob", cfg)
+		Expect(alice).To(HaveLen(2))
+		Expect(alice[1].key).NotTo(Equal(bob[1].key))
+	})
+
+	It("should share one bucket between the caretakers nobody knows", func() {
+		rex, fido := actionBuckets(pet("rex"), "", cfg), actionBuckets(pet("fido"), "", cfg)
+		Expect(rex).To(HaveLen(2))
+		Expect(rex[1].kind).To(Equal("caretaker"))
+		Expect(rex[1].subject).To(Equal(anonymousCaretaker))
+		Expect(rex[1].key).To(Equal(fido[1].key))
+		Expect(rex[1].key).NotTo(Equal(actionBuckets(pet("rex"), anonymousCaretaker, cfg)[1].key))
+	})
+})
+
+var _ = Describe("rejectActions", func() {
+	ctx := context.Background()
+
+	It("should write the rejected actions once per cooldown", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(linuxfestv2025.AddToScheme(scheme)).To(Succeed())
+
+		pet := &linuxfestv2025.Pet{
+			ObjectMeta: metav1.ObjectMeta{Name: "rex", Namespace: "default"},
+			Spec:       linuxfestv2025.PetSpec{Nickname: "Rex", DecayInterval: metav1.Duration{Duration: time.Minute}},
+		}
+		var writes int
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&linuxfestv2025.Pet{}).
+			WithObjects(pet).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					writes++
+					return c.SubResource(subResource).Update(ctx, obj, opts...)
+				},
+			}).
+			Build()
+		clock := clocktesting.NewFakeClock(time.Date(2025, 4, 26, 12, 0, 0, 0, time.UTC))
+		recorder := record.NewFakeRecorder(10)
+		r := &PetReconciler{Client: c, Scheme: scheme, Recorder: recorder, Clock: clock}
+		limit := bucket{kind: "pet", subject: "Rex"}
+
+		result, err := r.rejectActions(ctx, pet, limit, 10*time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+		By("only counting the actions rejected during the cooldown")
+		for range 5 {
+			clock.Step(time.Second)
+			result, err = r.rejectActions(ctx, pet, limit, 10*time.Second)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(result.RequeueAfter).To(Equal(5 * time.Second))
+		Expect(writes).To(Equal(1))
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(r.reportRejections(ctx, pet)).To(Succeed())
+		Expect(writes).To(Equal(1))
+
+		By("writing them once the cooldown ended")
+		clock.Step(5 * time.Second)
+		Expect(r.reportRejections(ctx, pet)).To(Succeed())
+		Expect(writes).To(Equal(2))
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(pet), pet)).To(Succeed())
+		Expect(pet.Status.RejectedActions).To(Equal(6))
+		Eventually(recorder.Events).Should(HaveLen(2))
+		<-recorder.Events
+		Expect(<-recorder.Events).To(ContainSubstring("Rex ignored 5 more requests"))
+
+		Expect(r.reportRejections(ctx, pet)).To(Succeed())
+		Expect(writes).To(Equal(2))
+	})
+})
+
+var _ = Describe("ceilSecond", func() {
+	It("should round up to the next second", func() {
+		t := time.Date(2025, 4, 26, 12, 0, 0, int(300*time.Millisecond), time.UTC)
+		Expect(ceilSecond(t)).To(Equal(time.Date(2025, 4, 26, 12, 0, 1, 0, time.UTC)))
+	})
+
+	It("should keep whole seconds", func() {
+		t := time.Date(2025, 4, 26, 12, 0, 1, 0, time.UTC)
+		Expect(ceilSecond(t)).To(Equal(t))
+	})
+})
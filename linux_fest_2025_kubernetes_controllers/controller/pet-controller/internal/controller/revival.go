@@ -0,0 +1,230 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/config"
+	"github.com/itzloop/pet-controller/pkg/engine"
+	"github.com/itzloop/pet-controller/pkg/engine/kube"
+)
+
+// Annotations attached to the audit event of a revival.
+const (
+	annotationHousehold = "linuxfest.example.com/household"
+	annotationCost      = "linuxfest.example.com/cost"
+	annotationRevivals  = "linuxfest.example.com/revivals"
+)
+
+// +kubebuilder:rbac:groups=linuxfest.example.com,resources=households,verbs=get;list;watch
+// +kubebuilder:rbac:groups=linuxfest.example.com,resources=households/status,verbs=get;update;patch
+
+// revive consumes the revive annotation and brings pet back to life when its
+// cooldown has passed and its household can pay for it.
+func (r *PetReconciler) revive(ctx context.Context, pet *linuxfestv2025.Pet, cfg *config.Configuration) (ctrl.Result, error) {
+	ctx, span := tracer.Start(ctx, "Revive")
+	defer span.End()
+	log := log.FromContext(ctx)
+
+	// 🧹 Remove the annotation first so a revival is never paid twice
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(pet), pet); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		cpy := pet.DeepCopy()
+		delete(cpy.Annotations, cfg.Annotations.Revive)
+		delete(cpy.Annotations, linuxfestv2025.RequesterAnnotation)
+		return r.Update(ctx, cpy)
+	})
+	if err != nil {
+		log.Error(err, "unable to remove revive annotation")
+		return ctrl.Result{}, recordError(span, err)
+	}
+
+	now := r.now()
+	if rejection := revivalRejection(pet, cfg, now); rejection != "" {
+		r.rejectRevival(ctx, pet, rejection)
+		return ctrl.Result{RequeueAfter: pet.Spec.DecayInterval.Duration}, nil
+	}
+
+	// 💰 Let the household pay for it
+	rejection, err := r.charge(ctx, pet, cfg)
+	if err != nil {
+		log.Error(err, "unable to charge household", "household", pet.Spec.Household)
+		// 📣 The annotation is gone, tell the owners to ask again
+		r.rejectRevival(ctx, pet, fmt.Sprintf("household %s could not be charged (%v), request the revival again",
+			pet.Spec.Household, err))
+		return ctrl.Result{}, recordError(span, err)
+	}
+	if rejection != "" {
+		r.rejectRevival(ctx, pet, rejection)
+		return ctrl.Result{RequeueAfter: pet.Spec.DecayInterval.Duration}, nil
+	}
+
+	// 🪄 Start over with the initial food, love and health
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(pet), pet); err != nil {
+			return err
+		}
+
+		cpy := pet.DeepCopy()
+		state, events := engine.Revive(kube.Pet(cpy), cfg.Rules(cpy.Spec.Species), now)
+		kube.Apply(cpy, state, now)
+		cpy.Status.Revivals++
+		cpy.Status.LastRevivalTime = v1.NewTime(now)
+		cpy.Status.ModifiedTime = v1.NewTime(now)
+
+		if err := r.Status().Update(ctx, cpy); err != nil {
+			return err
+		}
+
+		log.Info("Revived pet", "household", cpy.Spec.Household, "cost", cfg.Revival.Cost, "revivals", cpy.Status.Revivals)
+		annotations := valueAnnotations(pet.Status, cpy.Status)
+		annotations[annotationHousehold] = cpy.Spec.Household
+		annotations[annotationCost] = strconv.Itoa(cfg.Revival.Cost)
+		annotations[annotationRevivals] = strconv.Itoa(cpy.Status.Revivals)
+		r.event(ctx, cpy, annotations, corev1.EventTypeNormal, "Revived",
+			fmt.Sprintf("🪄 %s was revived for the %s time", cpy.Spec.Nickname, ordinal(cpy.Status.Revivals)))
+		r.recordEvents(ctx, pet.Status, cpy, events)
+		r.recordHistory(ctx, cpy, "Revived", cfg)
+		return nil
+	})
+	if err != nil {
+		log.Error(err, "unable to revive pet")
+
+		// 💸 The household paid for a revival that never happened, give the stock back
+		if refundErr := r.refund(ctx, pet, cfg); refundErr != nil {
+			log.Error(refundErr, "unable to refund household", "household", pet.Spec.Household)
+			r.rejectRevival(ctx, pet, fmt.Sprintf("the revival failed (%v) and household %s could not be refunded",
+				err, pet.Spec.Household))
+			return ctrl.Result{}, recordError(span, errors.Join(err, refundErr))
+		}
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		r.rejectRevival(ctx, pet, fmt.Sprintf("the revival failed (%v) and the household was refunded, request the revival again", err))
+		return ctrl.Result{}, recordError(span, err)
+	}
+
+	return ctrl.Result{RequeueAfter: pet.Spec.DecayInterval.Duration}, nil
+}
+
+// revivalRejection explains why pet can not be revived at now, it is empty when it can.
+func revivalRejection(pet *linuxfestv2025.Pet, cfg *config.Configuration, now time.Time) string {
+	if !isDead(pet) {
+		return "only dead pets can be revived"
+	}
+
+	last := pet.Status.LastRevivalTime
+	if !last.IsZero() && now.Sub(last.Time) < cfg.Revival.Cooldown.Duration {
+		wait := cfg.Revival.Cooldown.Duration - now.Sub(last.Time)
+		return fmt.Sprintf("revived too recently, try again in %s", wait.Round(time.Second))
+	}
+	return ""
+}
+
+// charge takes the revival cost from pet's household. It returns why the
+// household can not pay, or an empty string once it paid.
+func (r *PetReconciler) charge(ctx context.Context, pet *linuxfestv2025.Pet, cfg *config.Configuration) (string, error) {
+	if cfg.Revival.Cost == 0 {
+		return "", nil
+	}
+	if pet.Spec.Household == "" {
+		return "the pet has no household to pay for the revival", nil
+	}
+
+	var rejection string
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var household linuxfestv2025.Household
+		if err := r.Get(ctx, client.ObjectKey{Namespace: pet.Namespace, Name: pet.Spec.Household}, &household); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				rejection = fmt.Sprintf("household %s does not exist", pet.Spec.Household)
+				return nil
+			}
+			return err
+		}
+
+		if household.Available() < cfg.Revival.Cost {
+			rejection = fmt.Sprintf("household %s has %d stock left, a revival costs %d",
+				household.Name, household.Available(), cfg.Revival.Cost)
+			return nil
+		}
+
+		cpy := household.DeepCopy()
+		cpy.Status.Spent += cfg.Revival.Cost
+		cpy.Status.Revivals++
+		return r.Status().Update(ctx, cpy)
+	})
+	return rejection, err
+}
+
+// refund gives the revival cost charged for pet back to its household.
+func (r *PetReconciler) refund(ctx context.Context, pet *linuxfestv2025.Pet, cfg *config.Configuration) error {
+	if cfg.Revival.Cost == 0 || pet.Spec.Household == "" {
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var household linuxfestv2025.Household
+		if err := r.Get(ctx, client.ObjectKey{Namespace: pet.Namespace, Name: pet.Spec.Household}, &household); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		cpy := household.DeepCopy()
+		cpy.Status.Spent = max(cpy.Status.Spent-cfg.Revival.Cost, 0)
+		cpy.Status.Revivals = max(cpy.Status.Revivals-1, 0)
+		return r.Status().Update(ctx, cpy)
+	})
+}
+
+// rejectRevival records why a revival of pet was rejected.
+func (r *PetReconciler) rejectRevival(ctx context.Context, pet *linuxfestv2025.Pet, rejection string) {
+	log.FromContext(ctx).Info("Rejected revival", "reason", rejection)
+	annotations := valueAnnotations(pet.Status, pet.Status)
+	annotations[annotationHousehold] = pet.Spec.Household
+	r.event(ctx, pet, annotations, corev1.EventTypeWarning, "ReviveRejected",
+		fmt.Sprintf("🚫 %s could not be revived: %s", pet.Spec.Nickname, rejection))
+}
+
+// ordinal formats n as an English ordinal number.
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
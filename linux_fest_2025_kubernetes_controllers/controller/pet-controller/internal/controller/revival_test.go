@@ -0,0 +1,237 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/config"
+)
+
+var _ = Describe("Revival", func() {
+	ctx := context.Background()
+
+	fetch := func(obj client.Object) func() (client.Object, error) {
+		return func() (client.Object, error) {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+			return obj, err
+		}
+	}
+
+	// newHousehold creates a household with stock to spend on revivals.
+	newHousehold := func(name string, stock int) *linuxfestv2025.Household {
+		GinkgoHelper()
+
+		household := &linuxfestv2025.Household{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       linuxfestv2025.HouseholdSpec{Stock: stock},
+		}
+		Expect(k8sClient.Create(ctx, household)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, household))).To(Succeed())
+		})
+		return household
+	}
+
+	// newPet creates a pet of household, waits for it to be initialized and
+	// lets it die when dead is set.
+	newPet := func(name, nickname, household string, dead bool) *linuxfestv2025.Pet {
+		GinkgoHelper()
+
+		pet := &linuxfestv2025.Pet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: linuxfestv2025.PetSpec{
+				Nickname:      nickname,
+				Household:     household,
+				DecayInterval: metav1.Duration{Duration: time.Second},
+			},
+		}
+		Expect(k8sClient.Create(ctx, pet)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, pet))).To(Succeed())
+		})
+		Eventually(fetch(pet)).WithTimeout(timeout).WithPolling(interval).
+			Should(HaveField("Status.Initialized", BeTrue()))
+
+		if dead {
+			Expect(retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(pet), pet); err != nil {
+					return err
+				}
+				pet.Status.Food, pet.Status.Love, pet.Status.Health = 0, 0, 0
+				meta.SetStatusCondition(&pet.Status.Conditions, metav1.Condition{
+					Type:   linuxfestv2025.PetConditionDead,
+					Status: metav1.ConditionTrue,
+					Reason: "Neglected",
+				})
+				return k8sClient.Status().Update(ctx, pet)
+			})).To(Succeed())
+		}
+		return pet
+	}
+
+	// revive asks the controller to revive pet and waits for it to take the request.
+	revive := func(pet *linuxfestv2025.Pet) {
+		GinkgoHelper()
+
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pet), pet)).To(Succeed())
+		if pet.Annotations == nil {
+			pet.Annotations = map[string]string{}
+		}
+		pet.Annotations["linuxfest.example.com/revive"] = "true"
+		Expect(k8sClient.Update(ctx, pet)).To(Succeed())
+
+		Eventually(fetch(pet)).WithTimeout(timeout).WithPolling(interval).
+			Should(HaveField("ObjectMeta.Annotations", Not(HaveKey("linuxfest.example.com/revive"))))
+	}
+
+	It("should revive dead pets and charge their household", func() {
+		household := newHousehold("lazarus", 30)
+		pet := newPet("phoenix", "Phoenix", household.Name, true)
+
+		revive(pet)
+
+		Eventually(fetch(pet)).WithTimeout(timeout).WithPolling(interval).Should(And(
+			HaveField("Status.Revivals", 1),
+			HaveField("Status.Health", 100),
+			HaveField("Status.LastRevivalTime.Time", BeTemporally("==", fakeClock.Now())),
+		))
+		Expect(meta.IsStatusConditionTrue(pet.Status.Conditions, linuxfestv2025.PetConditionDead)).To(BeFalse())
+		Eventually(fetch(household)).WithTimeout(timeout).WithPolling(interval).Should(And(
+			HaveField("Status.Spent", 10),
+			HaveField("Status.Revivals", 1),
+		))
+		Eventually(eventsOf("Phoenix")).WithTimeout(timeout).
+			Should(ContainElement(HavePrefix("Normal Revived ")))
+	})
+
+	It("should reject revivals the household can not afford", func() {
+		household := newHousehold("broke", 5)
+		pet := newPet("ember", "Ember", household.Name, true)
+
+		revive(pet)
+
+		Eventually(eventsOf("Ember")).WithTimeout(timeout).
+			Should(ContainElement(And(HavePrefix("Warning ReviveRejected "), ContainSubstring("5 stock left"))))
+		Expect(fetch(household)()).To(HaveField("Status.Spent", 0))
+		Expect(meta.IsStatusConditionTrue(pet.Status.Conditions, linuxfestv2025.PetConditionDead)).To(BeTrue())
+	})
+
+	It("should reject revivals during the cooldown", func() {
+		household := newHousehold("impatient", 30)
+		pet := newPet("cinder", "Cinder", household.Name, true)
+		Expect(retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(pet), pet); err != nil {
+				return err
+			}
+			pet.Status.LastRevivalTime = metav1.NewTime(fakeClock.Now().Add(-10 * time.Minute))
+			return k8sClient.Status().Update(ctx, pet)
+		})).To(Succeed())
+
+		revive(pet)
+
+		Eventually(eventsOf("Cinder")).WithTimeout(timeout).
+			Should(ContainElement(And(HavePrefix("Warning ReviveRejected "), ContainSubstring("revived too recently"))))
+		Expect(fetch(household)()).To(HaveField("Status.Spent", 0))
+		Expect(fetch(pet)()).To(HaveField("Status.Revivals", 0))
+	})
+
+	It("should reject revivals of living pets", func() {
+		household := newHousehold("hopeful", 30)
+		pet := newPet("spark", "Spark", household.Name, false)
+
+		revive(pet)
+
+		Eventually(eventsOf("Spark")).WithTimeout(timeout).
+			Should(ContainElement(And(HavePrefix("Warning ReviveRejected "), ContainSubstring("only dead pets"))))
+		Expect(fetch(household)()).To(HaveField("Status.Spent", 0))
+		Expect(fetch(pet)()).To(HaveField("Status.Revivals", 0))
+	})
+
+	It("should refund the household when the pet can not be revived", func() {
+		household := newHousehold("unlucky", 30)
+		pet := newPet("ash", "Ash", household.Name, true)
+
+		// 💥 The household pays but every pet status write fails
+		c, err := client.NewWithWatch(cfg, client.Options{Scheme: scheme.Scheme})
+		Expect(err).NotTo(HaveOccurred())
+		r := &PetReconciler{
+			Client: interceptor.NewClient(c, interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					if _, ok := obj.(*linuxfestv2025.Pet); ok {
+						return errors.New("pet status is unavailable")
+					}
+					return c.SubResource(subResource).Update(ctx, obj, opts...)
+				},
+			}),
+			Scheme:   scheme.Scheme,
+			Recorder: record.NewFakeRecorder(10),
+			Clock:    fakeClock,
+		}
+
+		recorder := r.Recorder.(*record.FakeRecorder)
+		_, err = r.revive(ctx, pet, config.Default())
+		Expect(err).To(MatchError(ContainSubstring("pet status is unavailable")))
+		Expect(recorder.Events).To(Receive(And(HavePrefix("Warning ReviveRejected "), ContainSubstring("request the revival again"))))
+
+		Expect(fetch(household)()).To(And(
+			HaveField("Status.Spent", 0),
+			HaveField("Status.Revivals", 0),
+		))
+		Expect(fetch(pet)()).To(HaveField("Status.Revivals", 0))
+	})
+	It("should tell the owners to ask again when the household can not be charged", func() {
+		household := newHousehold("offline", 30)
+		pet := newPet("ember", "Ember", household.Name, true)
+
+		// 💥 Every household status write fails
+		c, err := client.NewWithWatch(cfg, client.Options{Scheme: scheme.Scheme})
+		Expect(err).NotTo(HaveOccurred())
+		recorder := record.NewFakeRecorder(10)
+		r := &PetReconciler{
+			Client: interceptor.NewClient(c, interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					if _, ok := obj.(*linuxfestv2025.Household); ok {
+						return errors.New("household status is unavailable")
+					}
+					return c.SubResource(subResource).Update(ctx, obj, opts...)
+				},
+			}),
+			Scheme:   scheme.Scheme,
+			Recorder: recorder,
+			Clock:    fakeClock,
+		}
+
+		_, err = r.revive(ctx, pet, config.Default())
+		Expect(err).To(MatchError(ContainSubstring("household status is unavailable")))
+		Expect(recorder.Events).To(Receive(And(HavePrefix("Warning ReviveRejected "), ContainSubstring("request the revival again"))))
+		Expect(fetch(household)()).To(HaveField("Status.Spent", 0))
+	})
+})
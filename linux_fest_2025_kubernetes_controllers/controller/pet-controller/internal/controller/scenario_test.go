@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/itzloop/pet-controller/internal/scenario"
+)
+
+// recordedEvents returns every event recorded by the pet controller so far.
+func recordedEvents() []string {
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+
+	return slices.Clone(events)
+}
+
+// 📜 Every file in testdata/scenarios is a gameplay regression case, add new
+// cases there instead of writing Go.
+var _ = Describe("Scenarios", func() {
+	paths, err := filepath.Glob(filepath.Join("testdata", "scenarios", "*.yaml"))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, path := range paths {
+		It("should pass "+filepath.Base(path), func() {
+			s, err := scenario.Load(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			runner := &scenario.Runner{
+				Client:    k8sClient,
+				Clock:     fakeClock,
+				Events:    recordedEvents,
+				Namespace: "default",
+			}
+			Expect(runner.Run(context.Background(), s)).To(Succeed())
+		})
+	}
+})
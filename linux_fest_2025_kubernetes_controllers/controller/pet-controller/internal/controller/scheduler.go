@@ -0,0 +1,318 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DecayOptions tunes how due pets are decayed.
+type DecayOptions struct {
+	// Workers is the number of pets decayed at the same time, 10 when zero.
+	Workers int
+
+	// BatchSize is the most due pets taken from the queue at once, 500 when zero.
+	BatchSize int
+
+	// Resolution is how often the queue is checked for due pets, 100ms when zero.
+	Resolution time.Duration
+}
+
+// decayScheduler decays pets from a single time-ordered queue. Reconciles only
+// tell it when a pet is due next, so pets don't each hold a requeue in the work
+// queue and due pets are decayed in batches by a fixed number of workers. A pet
+// is queued at most once and decayed with one status patch for all the
+// intervals it is overdue.
+type decayScheduler struct {
+	// now tells which pets are due, it is the reconciler's clock.
+	now func() time.Time
+
+	// decay decays a due pet and schedules it again.
+	decay func(ctx context.Context, key types.NamespacedName) error
+
+	workers    int
+	batchSize  int
+	resolution time.Duration
+
+	mu    sync.Mutex
+	queue dueQueue
+	items map[types.NamespacedName]*dueItem
+
+	// running is since when the batch being decayed has been waiting.
+	running time.Time
+}
+
+func newDecayScheduler(now func() time.Time, opts DecayOptions, decay func(context.Context, types.NamespacedName) error) *decayScheduler {
+	s := &decayScheduler{
+		now:        now,
+		decay:      decay,
+		workers:    opts.Workers,
+		batchSize:  opts.BatchSize,
+		resolution: opts.Resolution,
+		items:      map[types.NamespacedName]*dueItem{},
+	}
+	if s.workers <= 0 {
+		s.workers = 10
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = 500
+	}
+	if s.resolution <= 0 {
+		s.resolution = 100 * time.Millisecond
+	}
+	return s
+}
+
+// schedule makes the pet with key due at due. Scheduling a pet again replaces
+// its due time, so a pet is never decayed twice for the same interval.
+func (s *decayScheduler) schedule(key types.NamespacedName, due time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// ⌛ A pet scheduled late only starts waiting once it is scheduled
+	now := s.now()
+	waiting := due
+	if now.After(due) {
+		waiting = now
+	}
+
+	if item, ok := s.items[key]; ok {
+		// 🔁 A pet that is still overdue keeps waiting since it first did
+		if !item.due.After(now) && !due.After(now) && item.waiting.Before(waiting) {
+			waiting = item.waiting
+		}
+		item.due, item.waiting = due, waiting
+		heap.Fix(&s.queue, item.index)
+	} else {
+		s.push(&dueItem{key: key, due: due, waiting: waiting})
+	}
+	decayQueueLength.Set(float64(len(s.queue)))
+}
+
+// retry puts back a pet that failed to decay, still waiting since it first did.
+// A pet scheduled again meanwhile keeps its new due time.
+func (s *decayScheduler) retry(item dueItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[item.key]; !ok {
+		s.push(&item)
+	}
+	decayQueueLength.Set(float64(len(s.queue)))
+}
+
+func (s *decayScheduler) push(item *dueItem) {
+	heap.Push(&s.queue, item)
+	s.items[item.key] = item
+}
+
+// forget removes the pet with key from the queue.
+func (s *decayScheduler) forget(key types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if item, ok := s.items[key]; ok {
+		heap.Remove(&s.queue, item.index)
+		delete(s.items, key)
+	}
+	decayQueueLength.Set(float64(len(s.queue)))
+}
+
+// pop removes up to max pets that are due at now from the queue, earliest first.
+func (s *decayScheduler) pop(now time.Time, max int) []dueItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var items []dueItem
+	for len(items) < max && len(s.queue) > 0 && !s.queue[0].due.After(now) {
+		item := heap.Pop(&s.queue).(*dueItem)
+		delete(s.items, item.key)
+		items = append(items, *item)
+	}
+	decayQueueLength.Set(float64(len(s.queue)))
+	return items
+}
+
+// overdue returns the number of pets that are due at now.
+func (s *decayScheduler) overdue(now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	s.walkDue(now, func(*dueItem) { count++ })
+	return count
+}
+
+// walkDue calls fn with every pet that is due at now. Only the part of the heap
+// holding due pets is visited.
+func (s *decayScheduler) walkDue(now time.Time, fn func(*dueItem)) {
+	stack := []int{0}
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if i >= len(s.queue) || s.queue[i].due.After(now) {
+			continue
+		}
+		fn(s.queue[i])
+		stack = append(stack, 2*i+1, 2*i+2)
+	}
+}
+
+// lag returns how long the pet that has been waiting the longest to be decayed
+// has been waiting at now.
+func (s *decayScheduler) lag(now time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	earliest := s.running
+	s.walkDue(now, func(item *dueItem) {
+		if earliest.IsZero() || item.waiting.Before(earliest) {
+			earliest = item.waiting
+		}
+	})
+	if earliest.IsZero() || earliest.After(now) {
+		return 0
+	}
+	return now.Sub(earliest)
+}
+
+// Start decays due pets until ctx is cancelled.
+func (s *decayScheduler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.resolution)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.drain(ctx)
+		}
+	}
+}
+
+// drain decays the pets due now batch by batch. Pets that failed to decay are
+// retried on the next drain rather than straight away, and stay as overdue.
+func (s *decayScheduler) drain(ctx context.Context) {
+	now := s.now()
+	decayBacklog.Set(float64(s.overdue(now)))
+
+	var failed []dueItem
+	for ctx.Err() == nil {
+		batch := s.pop(now, s.batchSize)
+		if len(batch) == 0 {
+			break
+		}
+		decayBatchSize.Observe(float64(len(batch)))
+		failed = append(failed, s.run(ctx, batch)...)
+	}
+
+	for _, item := range failed {
+		s.retry(item)
+	}
+	decayBacklog.Set(float64(s.overdue(s.now())))
+}
+
+// run decays batch with the scheduler's workers and returns the pets that failed.
+func (s *decayScheduler) run(ctx context.Context, batch []dueItem) []dueItem {
+	s.mu.Lock()
+	s.running = batch[0].waiting
+	for _, item := range batch {
+		if item.waiting.Before(s.running) {
+			s.running = item.waiting
+		}
+	}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.running = time.Time{}
+		s.mu.Unlock()
+	}()
+
+	items := make(chan dueItem)
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed []dueItem
+	)
+	for range min(s.workers, len(batch)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range items {
+				if err := s.decay(ctx, item.key); err != nil {
+					mu.Lock()
+					failed = append(failed, item)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, item := range batch {
+		items <- item
+	}
+	close(items)
+	wg.Wait()
+
+	return failed
+}
+
+// dueItem is a pet waiting in the decay queue.
+type dueItem struct {
+	key types.NamespacedName
+	due time.Time
+
+	// waiting is when the pet started waiting to be decayed, when it was due or
+	// when it was scheduled if that was later.
+	waiting time.Time
+
+	index int
+}
+
+// dueQueue is a min-heap of pets ordered by when they are due.
+type dueQueue []*dueItem
+
+func (q dueQueue) Len() int           { return len(q) }
+func (q dueQueue) Less(i, j int) bool { return q[i].due.Before(q[j].due) }
+
+func (q dueQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *dueQueue) Push(x any) {
+	item := x.(*dueItem)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+
+func (q *dueQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return item
+}
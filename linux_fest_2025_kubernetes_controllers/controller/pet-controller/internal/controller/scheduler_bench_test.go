@@ -0,0 +1,256 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/testenv"
+)
+
+// BenchmarkDecay measures how many pets per second the decay scheduler decays
+// against an envtest API server, and how many writes and reconciles the manager
+// needs for it. Every iteration steps the clock by a number of decay intervals
+// and waits until each living pet decayed. Stepping several intervals at once
+// shows the missed intervals being folded into a single write. It runs 10000
+// pets, BENCH_PETS overrides the number:
+//
+//	KUBEBUILDER_ASSETS=... go test ./internal/controller -run '^$' -bench Decay -benchtime 5x
+func BenchmarkDecay(b *testing.B) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		b.Skip("KUBEBUILDER_ASSETS is not set, run make bench")
+	}
+	pets := 10000
+	if n := os.Getenv("BENCH_PETS"); n != "" {
+		var err error
+		if pets, err = strconv.Atoi(n); err != nil {
+			b.Fatalf("invalid BENCH_PETS %q: %v", n, err)
+		}
+	}
+	const interval = 10 * time.Second
+
+	env := testenv.New()
+	restCfg, err := env.Start()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer func() { _ = env.Stop() }()
+
+	// 🏎️ Don't let client side throttling hide the throughput of the scheduler
+	restCfg.QPS, restCfg.Burst = 5000, 10000
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		b.Fatal(err)
+	}
+	if err := linuxfestv2025.AddToScheme(scheme); err != nil {
+		b.Fatal(err)
+	}
+
+	// ✍️ Count every write the manager sends to the API server
+	var writes atomic.Int64
+	mgrCfg := rest.CopyConfig(restCfg)
+	mgrCfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodGet {
+				writes.Add(1)
+			}
+			return rt.RoundTrip(req)
+		})
+	})
+
+	mgr, err := ctrl.NewManager(mgrCfg, ctrl.Options{
+		Scheme:  scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	clk := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	if err := (&PetReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: &record.FakeRecorder{},
+		Clock:    clk,
+		Decay:    DecayOptions{Workers: 100, BatchSize: 1000},
+	}).SetupWithManager(mgr); err != nil {
+		b.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			b.Error(err)
+		}
+	}()
+
+	c, err := client.New(restCfg, client.Options{Scheme: scheme})
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := createPets(ctx, c, pets, interval); err != nil {
+		b.Fatal(err)
+	}
+
+	// 🐣 Wait for the controller to initialize every pet
+	waitForPets(ctx, b, mgr.GetClient(), pets, func(pet *linuxfestv2025.Pet) bool {
+		return pet.Status.Initialized
+	})
+
+	for _, intervals := range []int{1, 5} {
+		b.Run(fmt.Sprintf("intervals=%d", intervals), func(b *testing.B) {
+			decayed := 0
+			writes.Store(0)
+			reconciled := reconciles(b, "pet")
+			b.ResetTimer()
+			for range b.N {
+				clk.Step(time.Duration(intervals) * interval)
+				now := clk.Now()
+				decayed += waitForPets(ctx, b, mgr.GetClient(), pets, func(pet *linuxfestv2025.Pet) bool {
+					return isDead(pet) || !pet.Status.ModifiedTime.Time.Before(now)
+				})
+			}
+			b.StopTimer()
+
+			b.ReportMetric(float64(decayed*intervals)/b.Elapsed().Seconds(), "decays/s")
+			b.ReportMetric(float64(writes.Load())/float64(b.N), "writes/op")
+			b.ReportMetric((reconciles(b, "pet")-reconciled)/float64(b.N), "reconciles/op")
+			if decayed > 0 {
+				b.ReportMetric(float64(writes.Load())/float64(decayed*intervals), "writes/decay")
+			}
+		})
+	}
+}
+
+// reconciles returns how many reconciles the controller named controller ran so far.
+func reconciles(b *testing.B, controller string) float64 {
+	b.Helper()
+
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var total float64
+	for _, family := range families {
+		if family.GetName() != "controller_runtime_reconcile_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "controller" && label.GetValue() == controller {
+					total += metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return total
+}
+
+// roundTripperFunc turns a function into an http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// createPets creates n pets in the default namespace in parallel.
+func createPets(ctx context.Context, c client.Client, n int, interval time.Duration) error {
+	names := make(chan int)
+	errs := make(chan error, n)
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range names {
+				pet := &linuxfestv2025.Pet{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("bench-%d", i)},
+					Spec: linuxfestv2025.PetSpec{
+						Nickname:      fmt.Sprintf("Bench %d", i),
+						DecayInterval: metav1.Duration{Duration: interval},
+					},
+				}
+				if err := c.Create(ctx, pet); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	for i := range n {
+		names <- i
+	}
+	close(names)
+	wg.Wait()
+	close(errs)
+
+	return <-errs
+}
+
+// waitForPets waits until done holds for all n pets in the cache and returns
+// the number of living pets.
+func waitForPets(ctx context.Context, b *testing.B, c client.Reader, n int, done func(*linuxfestv2025.Pet) bool) int {
+	b.Helper()
+
+	deadline := time.Now().Add(5 * time.Minute)
+	for time.Now().Before(deadline) {
+		var pets linuxfestv2025.PetList
+		if err := c.List(ctx, &pets, client.InNamespace("default"), client.UnsafeDisableDeepCopy); err != nil {
+			b.Fatal(err)
+		}
+
+		ready, living := 0, 0
+		for i := range pets.Items {
+			if done(&pets.Items[i]) {
+				ready++
+			}
+			if !isDead(&pets.Items[i]) {
+				living++
+			}
+		}
+		if ready == n {
+			return living
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	b.Fatalf("timed out waiting for %d pets", n)
+	return 0
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+)
+
+var _ = Describe("decayScheduler", func() {
+	var (
+		now     time.Time
+		mu      sync.Mutex
+		decayed []types.NamespacedName
+		fail    map[types.NamespacedName]bool
+		s       *decayScheduler
+	)
+
+	pet := func(name string) types.NamespacedName {
+		return types.NamespacedName{Namespace: "default", Name: name}
+	}
+
+	keys := func(items []dueItem) []types.NamespacedName {
+		var keys []types.NamespacedName
+		for _, item := range items {
+			keys = append(keys, item.key)
+		}
+		return keys
+	}
+
+	BeforeEach(func() {
+		now = time.Date(2025, 4, 26, 0, 0, 0, 0, time.UTC)
+		decayed = nil
+		fail = map[types.NamespacedName]bool{}
+		s = newDecayScheduler(func() time.Time { return now }, DecayOptions{Workers: 4, BatchSize: 2},
+			func(_ context.Context, key types.NamespacedName) error {
+				mu.Lock()
+				defer mu.Unlock()
+				if fail[key] {
+					return errors.New("conflict")
+				}
+				decayed = append(decayed, key)
+				return nil
+			})
+	})
+
+	It("should pop due pets earliest first in batches", func() {
+		s.schedule(pet("c"), now.Add(-time.Second))
+		s.schedule(pet("a"), now.Add(-3*time.Second))
+		s.schedule(pet("later"), now.Add(time.Second))
+		s.schedule(pet("b"), now.Add(-2*time.Second))
+
+		Expect(s.overdue(now)).To(Equal(3))
+		Expect(keys(s.pop(now, 2))).To(Equal([]types.NamespacedName{pet("a"), pet("b")}))
+		Expect(keys(s.pop(now, 2))).To(Equal([]types.NamespacedName{pet("c")}))
+		Expect(s.pop(now, 2)).To(BeEmpty())
+		Expect(s.overdue(now.Add(time.Second))).To(Equal(1))
+	})
+
+	It("should keep a single entry per pet", func() {
+		s.schedule(pet("a"), now.Add(-time.Second))
+		s.schedule(pet("a"), now.Add(time.Second))
+
+		Expect(s.pop(now, 10)).To(BeEmpty())
+		Expect(keys(s.pop(now.Add(time.Second), 10))).To(Equal([]types.NamespacedName{pet("a")}))
+	})
+
+	It("should forget pets", func() {
+		s.schedule(pet("a"), now)
+		s.schedule(pet("b"), now)
+		s.forget(pet("a"))
+		s.forget(pet("missing"))
+
+		Expect(keys(s.pop(now, 10))).To(Equal([]types.NamespacedName{pet("b")}))
+	})
+
+	It("should decay every due pet in one drain", func() {
+		for _, name := range []string{"a", "b", "c", "d", "e"} {
+			s.schedule(pet(name), now)
+		}
+		s.schedule(pet("later"), now.Add(time.Minute))
+
+		s.drain(context.Background())
+
+		Expect(decayed).To(ConsistOf(pet("a"), pet("b"), pet("c"), pet("d"), pet("e")))
+		Expect(s.overdue(now)).To(BeZero())
+	})
+
+	It("should retry pets that failed on the next drain", func() {
+		fail[pet("a")] = true
+		s.schedule(pet("a"), now)
+
+		s.drain(context.Background())
+		Expect(decayed).To(BeEmpty())
+		Expect(s.overdue(now)).To(Equal(1))
+
+		// ⌛ a failing pet stays as overdue as it was
+		now = now.Add(time.Minute)
+		s.drain(context.Background())
+		Expect(s.lag(now)).To(Equal(time.Minute))
+
+		fail[pet("a")] = false
+		s.drain(context.Background())
+		Expect(decayed).To(ConsistOf(pet("a")))
+	})
+
+	It("should report how long the pet waiting the longest has been waiting", func() {
+		Expect(s.lag(now)).To(BeZero())
+
+		s.schedule(pet("a"), now)
+		s.schedule(pet("later"), now.Add(time.Minute))
+		now = now.Add(30 * time.Second)
+		Expect(s.lag(now)).To(Equal(30 * time.Second))
+
+		// 🔁 scheduling a waiting pet again doesn't hide how long it waited
+		s.schedule(pet("a"), now.Add(-time.Second))
+		Expect(s.lag(now)).To(Equal(30 * time.Second))
+
+		s.drain(context.Background())
+		Expect(s.lag(now)).To(BeZero())
+	})
+
+	It("should only count the wait of pets scheduled late from when they were scheduled", func() {
+		s.schedule(pet("a"), now.Add(-time.Hour))
+		Expect(s.lag(now)).To(BeZero())
+
+		now = now.Add(time.Second)
+		Expect(s.lag(now)).To(Equal(time.Second))
+	})
+})
+
+var _ = Describe("decay", func() {
+	ctx := context.Background()
+
+	It("should fold every interval a pet is overdue into one write", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(linuxfestv2025.AddToScheme(scheme)).To(Succeed())
+
+		modified := time.Date(2025, 4, 26, 12, 0, 0, 0, time.UTC)
+		pet := &linuxfestv2025.Pet{
+			ObjectMeta: metav1.ObjectMeta{Name: "rex", Namespace: "default", CreationTimestamp: metav1.NewTime(modified)},
+			Spec: linuxfestv2025.PetSpec{
+				Nickname:      "Rex",
+				FoodDecayRate: 1,
+				LoveDecayRate: 1,
+				DecayInterval: metav1.Duration{Duration: 10 * time.Second},
+			},
+			Status: linuxfestv2025.PetStatus{
+				Initialized:  true,
+				Food:         80,
+				Love:         80,
+				Health:       100,
+				ModifiedTime: metav1.NewTime(modified),
+			},
+		}
+		var writes int
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&linuxfestv2025.Pet{}).
+			WithObjects(pet).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					writes++
+					return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+				},
+			}).
+			Build()
+		clock := clocktesting.NewFakeClock(modified.Add(35 * time.Second))
+		r := &PetReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10), Clock: clock}
+		r.scheduler = newDecayScheduler(r.now, DecayOptions{}, r.decay)
+
+		key := client.ObjectKeyFromObject(pet)
+		Expect(r.decay(ctx, key)).To(Succeed())
+
+		Expect(writes).To(Equal(1))
+		Expect(c.Get(ctx, key, pet)).To(Succeed())
+		Expect(pet.Status.Ticks).To(Equal(3))
+		Expect(pet.Status.ModifiedTime.Time).To(BeTemporally("==", modified.Add(30*time.Second)))
+
+		By("decaying it again one interval after the last folded one")
+		Expect(r.scheduler.items).To(HaveKey(key))
+		Expect(r.scheduler.items[key].due).To(BeTemporally("==", modified.Add(40*time.Second)))
+	})
+})
+
+var _ = Describe("overdueIntervals", func() {
+	modified := time.Date(2025, 4, 26, 12, 0, 0, 0, time.UTC)
+	pet := &linuxfestv2025.Pet{
+		Spec:   linuxfestv2025.PetSpec{DecayInterval: metav1.Duration{Duration: time.Minute}},
+		Status: linuxfestv2025.PetStatus{ModifiedTime: metav1.NewTime(modified)},
+	}
+
+	It("should count the whole intervals that ended", func() {
+		intervals, end := overdueIntervals(pet, modified.Add(150*time.Second))
+		Expect(intervals).To(Equal(2))
+		Expect(end).To(BeTemporally("==", modified.Add(2*time.Minute)))
+	})
+
+	It("should fold at most maxFoldedIntervals", func() {
+		intervals, end := overdueIntervals(pet, modified.Add(24*time.Hour))
+		Expect(intervals).To(Equal(maxFoldedIntervals))
+		Expect(end).To(BeTemporally("==", modified.Add(24*time.Hour)))
+	})
+})
+
+var _ = Describe("died", func() {
+	dead := &linuxfestv2025.Pet{Status: linuxfestv2025.PetStatus{Conditions: []metav1.Condition{
+		{Type: linuxfestv2025.PetConditionDead, Status: metav1.ConditionTrue},
+	}}}
+
+	It("should only pass deaths and revivals", func() {
+		Expect(died.Update(event.UpdateEvent{ObjectOld: &linuxfestv2025.Pet{}, ObjectNew: dead})).To(BeTrue())
+		Expect(died.Update(event.UpdateEvent{ObjectOld: dead, ObjectNew: &linuxfestv2025.Pet{}})).To(BeTrue())
+		Expect(died.Update(event.UpdateEvent{ObjectOld: &linuxfestv2025.Pet{}, ObjectNew: &linuxfestv2025.Pet{}})).To(BeFalse())
+	})
+})
@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/sharding"
+)
+
+// owns reports whether this replica reconciles the pet with key.
+func (r *PetReconciler) owns(key types.NamespacedName) bool {
+	return r.Shards == nil || r.Shards.Owns(key)
+}
+
+// labelShard shows the shard of pet in its labels when sharding is enabled.
+func (r *PetReconciler) labelShard(ctx context.Context, pet *linuxfestv2025.Pet) error {
+	if r.Shards == nil {
+		return nil
+	}
+
+	shard := strconv.Itoa(r.Shards.Shard(client.ObjectKeyFromObject(pet)))
+	if pet.Labels[sharding.Label] == shard {
+		return nil
+	}
+
+	patch := client.MergeFrom(pet.DeepCopy())
+	if pet.Labels == nil {
+		pet.Labels = map[string]string{}
+	}
+	pet.Labels[sharding.Label] = shard
+	return client.IgnoreNotFound(r.Patch(ctx, pet, patch))
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/config"
+	"github.com/itzloop/pet-controller/pkg/engine"
+	"github.com/itzloop/pet-controller/pkg/engine/kube"
+)
+
+// friendsIndex indexes pets by the names in their spec.friends.
+const friendsIndex = "spec.friends"
+
+// indexFriends is the indexer function for friendsIndex.
+func indexFriends(obj client.Object) []string {
+	return obj.(*linuxfestv2025.Pet).Spec.Friends
+}
+
+// friendsOf enqueues the pets that count obj among their friends, so they notice
+// when it becomes happy or dies.
+func (r *PetReconciler) friendsOf(ctx context.Context, obj client.Object) []reconcile.Request {
+	var pets linuxfestv2025.PetList
+	if err := r.List(ctx, &pets,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{friendsIndex: obj.GetName()},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list friends", "pet", obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(pets.Items))
+	for _, pet := range pets.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&pet)})
+	}
+	return requests
+}
+
+// friends returns the friends of pet that exist.
+func (r *PetReconciler) friends(ctx context.Context, pet *linuxfestv2025.Pet) ([]linuxfestv2025.Pet, error) {
+	friends := make([]linuxfestv2025.Pet, 0, len(pet.Spec.Friends))
+	for _, name := range pet.Spec.Friends {
+		if name == pet.Name {
+			continue
+		}
+
+		var friend linuxfestv2025.Pet
+		if err := r.Get(ctx, client.ObjectKey{Namespace: pet.Namespace, Name: name}, &friend); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				continue
+			}
+			return nil, err
+		}
+		friends = append(friends, friend)
+	}
+	return friends, nil
+}
+
+// friendshipBonus is the love pet gains this decay interval from its happy friends.
+func friendshipBonus(pet *linuxfestv2025.Pet, friends []linuxfestv2025.Pet, rules engine.Rules) int {
+	states := make([]engine.State, 0, len(friends))
+	for i := range friends {
+		states = append(states, kube.State(&friends[i].Status))
+	}
+	return engine.FriendshipBonus(kube.State(&pet.Status), states, rules)
+}
+
+// mourn makes pet lose love for every friend that died since the last reconcile
+// and forgets friends that came back to life.
+func (r *PetReconciler) mourn(ctx context.Context, pet *linuxfestv2025.Pet, friends []linuxfestv2025.Pet, cfg *config.Configuration) error {
+	var dead []string
+	for _, friend := range friends {
+		if isDead(&friend) {
+			dead = append(dead, friend.Name)
+		}
+	}
+
+	var lost []string
+	for _, name := range dead {
+		if !slices.Contains(pet.Status.MournedFriends, name) {
+			lost = append(lost, name)
+		}
+	}
+	if len(lost) == 0 && len(dead) == len(pet.Status.MournedFriends) {
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(pet), pet); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		cpy := pet.DeepCopy()
+		now := r.now()
+		state, events := engine.Grieve(kube.Pet(cpy), cfg.Rules(cpy.Spec.Species), now, lost)
+		kube.Apply(cpy, state, now)
+		cpy.Status.MournedFriends = dead
+
+		if err := r.Status().Update(ctx, cpy); err != nil {
+			return err
+		}
+
+		r.recordEvents(ctx, pet.Status, cpy, events)
+		if len(lost) > 0 {
+			r.recordHistory(ctx, cpy, "Grieving", cfg)
+		}
+		*pet = *cpy
+		return nil
+	})
+}
+
+// isDead reports whether pet has died.
+func isDead(pet *linuxfestv2025.Pet) bool {
+	return meta.IsStatusConditionTrue(pet.Status.Conditions, linuxfestv2025.PetConditionDead)
+}
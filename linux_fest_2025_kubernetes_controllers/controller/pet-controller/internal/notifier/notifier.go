@@ -0,0 +1,422 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notifier delivers pet alerts to the sinks configured by NotificationPolicies.
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+)
+
+// Alert is the payload delivered to every sink.
+type Alert struct {
+	Reason    string    `json:"reason"`
+	Message   string    `json:"message"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Nickname  string    `json:"nickname"`
+	Food      int       `json:"food"`
+	Love      int       `json:"love"`
+	Time      time.Time `json:"time"`
+}
+
+// Sink delivers an alert to a single destination.
+type Sink interface {
+	Send(ctx context.Context, alert Alert) error
+}
+
+// +kubebuilder:rbac:groups=linuxfest.example.com,resources=notificationpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=linuxfest.example.com,resources=notificationpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+
+// PetNotifier sends pet alerts to the webhooks and mail servers of every matching
+// NotificationPolicy. Alerts are queued by Notify and delivered in the background
+// so slow sinks never block a reconcile. Every policy gets its own delivery worker
+// so a slow or unreachable sink only delays the alerts of its own policy.
+type PetNotifier struct {
+	client.Client
+
+	// APIReader reads Secrets straight from the API server so they are not cached.
+	APIReader client.Reader
+
+	// HTTPClient is used by webhook sinks.
+	HTTPClient *http.Client
+
+	// Backoff is the delay between webhook delivery retries.
+	Backoff wait.Backoff
+
+	queue chan Alert
+
+	mu      sync.Mutex
+	workers map[types.UID]chan delivery
+	sent    map[string]sentAlert
+	pruned  time.Time
+	now     func() time.Time
+}
+
+// delivery is an alert waiting for a policy worker.
+type delivery struct {
+	policy *linuxfestv2025.NotificationPolicy
+	alert  Alert
+}
+
+// sentAlert remembers when an alert was last sent and for how long it is muted.
+type sentAlert struct {
+	at     time.Time
+	repeat time.Duration
+}
+
+var (
+	// workerIdle is how long a policy worker waits for alerts before it exits.
+	workerIdle = 5 * time.Minute
+
+	// pruneInterval is how often sent alerts past their repeat interval are forgotten.
+	pruneInterval = time.Minute
+)
+
+// NewPetNotifier returns a PetNotifier that reads policies with c and secrets with reader.
+func NewPetNotifier(c client.Client, reader client.Reader) *PetNotifier {
+	return &PetNotifier{
+		Client:     c,
+		APIReader:  reader,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		Backoff:    wait.Backoff{Duration: time.Second, Factor: 2, Jitter: 0.1, Cap: time.Minute},
+		queue:      make(chan Alert, 100),
+		workers:    map[types.UID]chan delivery{},
+		sent:       map[string]sentAlert{},
+		now:        time.Now,
+	}
+}
+
+// Notify queues an alert for pet. Alerts are dropped when the queue is full.
+func (n *PetNotifier) Notify(ctx context.Context, pet *linuxfestv2025.Pet, reason, message string) {
+	alert := Alert{
+		Reason:    reason,
+		Message:   message,
+		Namespace: pet.Namespace,
+		Name:      pet.Name,
+		Nickname:  pet.Spec.Nickname,
+		Food:      pet.Status.Food,
+		Love:      pet.Status.Love,
+		Time:      n.now(),
+	}
+
+	select {
+	case n.queue <- alert:
+	default:
+		log.FromContext(ctx).Info("Notification queue is full, dropping alert", "reason", reason)
+	}
+}
+
+// Start hands queued alerts to the workers of their matching policies until ctx
+// is cancelled.
+func (n *PetNotifier) Start(ctx context.Context) error {
+	log := log.FromContext(ctx).WithName("notifier")
+	ctx = logr.NewContext(ctx, log)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case alert := <-n.queue:
+			policies, err := n.matching(ctx, alert)
+			if err != nil {
+				log.Error(err, "unable to match alert",
+					"namespace", alert.Namespace, "name", alert.Name, "reason", alert.Reason)
+			}
+			for _, policy := range policies {
+				n.dispatch(ctx, policy, alert)
+			}
+		}
+	}
+}
+
+// dispatch queues alert on the worker of policy, starting one when needed.
+func (n *PetNotifier) dispatch(ctx context.Context, policy *linuxfestv2025.NotificationPolicy, alert Alert) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	jobs, ok := n.workers[policy.UID]
+	if !ok {
+		jobs = make(chan delivery, cap(n.queue))
+		n.workers[policy.UID] = jobs
+		go n.work(ctx, policy.UID, jobs)
+	}
+
+	select {
+	case jobs <- delivery{policy: policy, alert: alert}:
+	default:
+		log.FromContext(ctx).Info("Policy queue is full, dropping alert",
+			"policy", policy.Name, "reason", alert.Reason)
+	}
+}
+
+// work delivers the alerts of a single policy. It exits once the policy has been
+// idle for workerIdle so deleted policies do not leak goroutines.
+func (n *PetNotifier) work(ctx context.Context, uid types.UID, jobs chan delivery) {
+	log := log.FromContext(ctx)
+
+	idle := time.NewTimer(workerIdle)
+	defer idle.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-jobs:
+			if err := n.deliver(ctx, job.policy, job.alert); err != nil {
+				log.Error(err, "unable to deliver alert", "namespace", job.alert.Namespace,
+					"name", job.alert.Name, "reason", job.alert.Reason)
+			}
+			idle.Reset(workerIdle)
+		case <-idle.C:
+			// dispatch queues under the same lock, so an empty queue here stays empty.
+			n.mu.Lock()
+			if len(jobs) == 0 {
+				delete(n.workers, uid)
+				n.mu.Unlock()
+				return
+			}
+			n.mu.Unlock()
+			idle.Reset(workerIdle)
+		}
+	}
+}
+
+// Deliver sends alert to the sinks of every policy in the pet's namespace that
+// matches it and has not already sent the same alert within its repeat interval.
+func (n *PetNotifier) Deliver(ctx context.Context, alert Alert) error {
+	policies, err := n.matching(ctx, alert)
+
+	errs := []error{err}
+	for _, policy := range policies {
+		errs = append(errs, n.deliver(ctx, policy, alert))
+	}
+	return errors.Join(errs...)
+}
+
+// matching returns the policies in the pet's namespace that cover alert. Policies
+// with an invalid selector are reported in the error and skipped.
+func (n *PetNotifier) matching(ctx context.Context, alert Alert) ([]*linuxfestv2025.NotificationPolicy, error) {
+	var pet linuxfestv2025.Pet
+	if err := n.Get(ctx, client.ObjectKey{Namespace: alert.Namespace, Name: alert.Name}, &pet); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	var policies linuxfestv2025.NotificationPolicyList
+	if err := n.List(ctx, &policies, client.InNamespace(alert.Namespace)); err != nil {
+		return nil, err
+	}
+
+	var matched []*linuxfestv2025.NotificationPolicy
+	var errs []error
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+
+		matches, err := policyMatches(policy, &pet, alert.Reason)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("policy %s: %w", policy.Name, err))
+			continue
+		}
+		if matches {
+			matched = append(matched, policy)
+		}
+	}
+
+	return matched, errors.Join(errs...)
+}
+
+// deliver sends alert to the sinks of policy that have not already sent it
+// within the policy's repeat interval, and records the outcome in the policy
+// status. Every sink is muted on its own, so when one sink fails only that sink
+// sends the alert again.
+func (n *PetNotifier) deliver(ctx context.Context, policy *linuxfestv2025.NotificationPolicy, alert Alert) error {
+	prefix := string(policy.UID) + "/" + alert.Name + "/" + alert.Reason + "/"
+	repeat := policy.Spec.RepeatInterval.Duration
+
+	var errs []error
+	attempted := false
+	sinks, sendErr := n.sinks(ctx, policy)
+	if sendErr != nil {
+		attempted = true
+	}
+	for _, sink := range sinks {
+		key := prefix + sink.name
+		if !n.due(key, alert.Time, repeat) {
+			continue
+		}
+
+		attempted = true
+		if err := sink.Send(ctx, alert); err != nil {
+			sendErr = errors.Join(sendErr, fmt.Errorf("%s: %w", sink.name, err))
+			continue
+		}
+		n.markSent(key, alert.Time, repeat)
+	}
+	if !attempted {
+		return nil
+	}
+	if sendErr != nil {
+		errs = append(errs, fmt.Errorf("policy %s: %w", policy.Name, sendErr))
+	}
+
+	if err := n.recordDelivery(ctx, policy, alert.Time, sendErr); err != nil {
+		errs = append(errs, fmt.Errorf("policy %s: updating status: %w", policy.Name, err))
+	}
+	return errors.Join(errs...)
+}
+
+// policyMatches reports whether policy applies to reason alerts of pet.
+func policyMatches(policy *linuxfestv2025.NotificationPolicy, pet *linuxfestv2025.Pet, reason string) (bool, error) {
+	if len(policy.Spec.Reasons) > 0 && !slices.Contains(policy.Spec.Reasons, reason) {
+		return false, nil
+	}
+	if policy.Spec.Selector == nil {
+		return true, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(policy.Spec.Selector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(pet.Labels)), nil
+}
+
+// due reports whether an alert with key may be sent at now.
+func (n *PetNotifier) due(key string, now time.Time, repeat time.Duration) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	last, ok := n.sent[key]
+	return !ok || now.Sub(last.at) >= repeat
+}
+
+// markSent mutes key for repeat and forgets alerts whose repeat interval has passed.
+func (n *PetNotifier) markSent(key string, now time.Time, repeat time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.sent[key] = sentAlert{at: now, repeat: repeat}
+
+	if now.Sub(n.pruned) < pruneInterval {
+		return
+	}
+	n.pruned = now
+	for k, sent := range n.sent {
+		if now.Sub(sent.at) >= sent.repeat {
+			delete(n.sent, k)
+		}
+	}
+}
+
+// namedSink is a sink of a policy with the name its sent alerts are muted under.
+type namedSink struct {
+	Sink
+	name string
+}
+
+// sinks builds the sinks configured by policy, resolving their secrets.
+func (n *PetNotifier) sinks(ctx context.Context, policy *linuxfestv2025.NotificationPolicy) ([]namedSink, error) {
+	var sinks []namedSink
+
+	for _, hook := range policy.Spec.Webhooks {
+		sink := &WebhookSink{
+			URL:        hook.URL,
+			MaxRetries: hook.MaxRetries,
+			Backoff:    n.Backoff,
+			Client:     n.HTTPClient,
+		}
+		if ref := hook.SigningSecretRef; ref != nil {
+			secret, err := n.secret(ctx, policy.Namespace, ref.Name)
+			if err != nil {
+				return nil, err
+			}
+			key, ok := secret.Data[ref.Key]
+			if !ok {
+				return nil, fmt.Errorf("secret %s has no key %q", ref.Name, ref.Key)
+			}
+			sink.Secret = key
+		}
+		sinks = append(sinks, namedSink{Sink: sink, name: "webhook/" + hook.URL})
+	}
+
+	if smtp := policy.Spec.SMTP; smtp != nil {
+		sink := &SMTPSink{
+			Addr: smtp.Host + ":" + strconv.Itoa(smtp.Port),
+			Host: smtp.Host,
+			From: smtp.From,
+			To:   smtp.To,
+		}
+		if ref := smtp.CredentialsSecretRef; ref != nil {
+			secret, err := n.secret(ctx, policy.Namespace, ref.Name)
+			if err != nil {
+				return nil, err
+			}
+			sink.Username = string(secret.Data["username"])
+			sink.Password = string(secret.Data["password"])
+		}
+		sinks = append(sinks, namedSink{Sink: sink, name: "smtp/" + sink.Addr})
+	}
+
+	return sinks, nil
+}
+
+func (n *PetNotifier) secret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	var secret corev1.Secret
+	if err := n.APIReader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &secret); err != nil {
+		return nil, err
+	}
+	return &secret, nil
+}
+
+// recordDelivery stores the outcome of a delivery in the policy's status.
+func (n *PetNotifier) recordDelivery(ctx context.Context, policy *linuxfestv2025.NotificationPolicy, now time.Time, sendErr error) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var latest linuxfestv2025.NotificationPolicy
+		if err := n.Get(ctx, client.ObjectKeyFromObject(policy), &latest); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		cpy := latest.DeepCopy()
+		if sendErr != nil {
+			cpy.Status.Failed++
+			cpy.Status.LastError = sendErr.Error()
+		} else {
+			cpy.Status.Sent++
+			cpy.Status.LastSentTime = metav1.NewTime(now)
+		}
+		return n.Status().Update(ctx, cpy)
+	})
+}
@@ -0,0 +1,348 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+)
+
+// receiver is an httptest webhook endpoint that records the alerts it receives
+// and fails the first failures requests with a 503.
+type receiver struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	failures   int
+	attempts   int
+	times      []time.Time
+	alerts     []Alert
+	signatures []string
+}
+
+func newReceiver(failures int) *receiver {
+	r := &receiver{failures: failures}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.attempts++
+		r.times = append(r.times, time.Now())
+		if r.attempts <= r.failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := io.ReadAll(req.Body)
+		var alert Alert
+		if err := json.Unmarshal(body, &alert); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.alerts = append(r.alerts, alert)
+		r.signatures = append(r.signatures, req.Header.Get(SignatureHeader))
+	}))
+	DeferCleanup(r.Close)
+	return r
+}
+
+func (r *receiver) Alerts() []Alert {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Alert(nil), r.alerts...)
+}
+
+var fastBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 2, Cap: 10 * time.Millisecond}
+
+var _ = Describe("WebhookSink", func() {
+	ctx := context.Background()
+	alert := Alert{Reason: "NeedFood", Namespace: "default", Name: "barky", Food: 20, Love: 80}
+
+	It("should sign the payload with the shared secret", func() {
+		recv := newReceiver(0)
+		sink := &WebhookSink{URL: recv.URL, Secret: []byte("s3cr3t"), Backoff: fastBackoff}
+
+		Expect(sink.Send(ctx, alert)).To(Succeed())
+		Expect(recv.Alerts()).To(ConsistOf(alert))
+
+		body, err := json.Marshal(alert)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recv.signatures).To(ConsistOf("sha256=" + Sign([]byte("s3cr3t"), body)))
+	})
+
+	It("should retry server errors with backoff", func() {
+		recv := newReceiver(2)
+		sink := &WebhookSink{URL: recv.URL, MaxRetries: 3, Backoff: fastBackoff}
+
+		Expect(sink.Send(ctx, alert)).To(Succeed())
+		Expect(recv.attempts).To(Equal(3))
+		Expect(recv.Alerts()).To(HaveLen(1))
+	})
+
+	It("should grow the delay between retries", func() {
+		recv := newReceiver(3)
+		sink := &WebhookSink{URL: recv.URL, MaxRetries: 3, Backoff: wait.Backoff{Duration: 25 * time.Millisecond, Factor: 2}}
+
+		Expect(sink.Send(ctx, alert)).To(Succeed())
+		Expect(recv.times).To(HaveLen(4))
+		for i := 1; i < len(recv.times); i++ {
+			Expect(recv.times[i].Sub(recv.times[i-1])).To(BeNumerically(">=", 25*time.Millisecond<<(i-1)))
+		}
+	})
+
+	It("should not wait longer than the cap", func() {
+		sink := &WebhookSink{MaxRetries: 5, Backoff: wait.Backoff{Duration: time.Second, Factor: 2, Cap: 3 * time.Second}}
+
+		backoff := sink.backoff()
+		var delays []time.Duration
+		for range sink.MaxRetries {
+			delays = append(delays, backoff.Step())
+		}
+		Expect(delays).To(Equal([]time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second, 3 * time.Second}))
+	})
+
+	It("should give up after MaxRetries", func() {
+		recv := newReceiver(10)
+		sink := &WebhookSink{URL: recv.URL, MaxRetries: 2, Backoff: fastBackoff}
+
+		Expect(sink.Send(ctx, alert)).NotTo(Succeed())
+		Expect(recv.attempts).To(Equal(3))
+	})
+
+	It("should not retry client errors", func() {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		DeferCleanup(srv.Close)
+		sink := &WebhookSink{URL: srv.URL, MaxRetries: 3, Backoff: fastBackoff}
+
+		Expect(sink.Send(ctx, alert)).To(MatchError(ContainSubstring("404")))
+	})
+})
+
+var _ = Describe("SMTPSink", func() {
+	It("should email every recipient", func() {
+		var gotAddr, gotFrom string
+		var gotTo []string
+		var gotMsg []byte
+		sendMail = func(addr string, _ smtp.Auth, from string, to []string, msg []byte) error {
+			gotAddr, gotFrom, gotTo, gotMsg = addr, from, to, msg
+			return nil
+		}
+		DeferCleanup(func() { sendMail = smtp.SendMail })
+
+		sink := &SMTPSink{Addr: "mail:587", Host: "mail", From: "pets@example.com", To: []string{"a@example.com", "b@example.com"}}
+		Expect(sink.Send(context.Background(), Alert{Reason: "Dead", Namespace: "default", Name: "barky", Message: "Barky died"})).To(Succeed())
+
+		Expect(gotAddr).To(Equal("mail:587"))
+		Expect(gotFrom).To(Equal("pets@example.com"))
+		Expect(gotTo).To(ConsistOf("a@example.com", "b@example.com"))
+		Expect(string(gotMsg)).To(ContainSubstring("Subject: [pet-controller] default/barky: Dead"))
+		Expect(string(gotMsg)).To(ContainSubstring("Barky died"))
+	})
+})
+
+var _ = Describe("PetNotifier", func() {
+	var (
+		ctx      context.Context
+		recv     *receiver
+		c        client.Client
+		notifier *PetNotifier
+		now      time.Time
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		recv = newReceiver(0)
+		now = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(linuxfestv2025.AddToScheme(scheme)).To(Succeed())
+
+		pet := &linuxfestv2025.Pet{
+			ObjectMeta: metav1.ObjectMeta{Name: "barky", Namespace: "default", Labels: map[string]string{"team": "a"}},
+			Spec:       linuxfestv2025.PetSpec{Nickname: "Barky"},
+		}
+		policy := &linuxfestv2025.NotificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "default", UID: "policy-uid"},
+			Spec: linuxfestv2025.NotificationPolicySpec{
+				Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+				Reasons:        []string{"NeedFood", "Dead"},
+				RepeatInterval: metav1.Duration{Duration: time.Hour},
+				Webhooks: []linuxfestv2025.WebhookSink{{
+					URL:              recv.URL,
+					SigningSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "hook"}, Key: "key"},
+				}},
+			},
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "hook", Namespace: "default"},
+			Data:       map[string][]byte{"key": []byte("s3cr3t")},
+		}
+
+		c = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(pet, policy, secret).
+			WithStatusSubresource(&linuxfestv2025.NotificationPolicy{}).
+			Build()
+		notifier = NewPetNotifier(c, c)
+		notifier.Backoff = fastBackoff
+		notifier.now = func() time.Time { return now }
+	})
+
+	alertAt := func(reason string, at time.Time) Alert {
+		return Alert{Reason: reason, Namespace: "default", Name: "barky", Time: at}
+	}
+
+	It("should deliver matching alerts and record them in the policy status", func() {
+		Expect(notifier.Deliver(ctx, alertAt("NeedFood", now))).To(Succeed())
+		Expect(recv.Alerts()).To(HaveLen(1))
+		Expect(recv.signatures[0]).To(HavePrefix("sha256="))
+
+		var policy linuxfestv2025.NotificationPolicy
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "team-a"}, &policy)).To(Succeed())
+		Expect(policy.Status.Sent).To(Equal(1))
+		Expect(policy.Status.LastSentTime.Time).To(BeTemporally("==", now))
+	})
+
+	It("should not page again within the repeat interval", func() {
+		Expect(notifier.Deliver(ctx, alertAt("NeedFood", now))).To(Succeed())
+		Expect(notifier.Deliver(ctx, alertAt("NeedFood", now.Add(10*time.Second)))).To(Succeed())
+		Expect(recv.Alerts()).To(HaveLen(1))
+
+		By("sending a different reason right away")
+		Expect(notifier.Deliver(ctx, alertAt("Dead", now.Add(20*time.Second)))).To(Succeed())
+		Expect(recv.Alerts()).To(HaveLen(2))
+
+		By("sending the same reason again after the repeat interval")
+		Expect(notifier.Deliver(ctx, alertAt("NeedFood", now.Add(time.Hour)))).To(Succeed())
+		Expect(recv.Alerts()).To(HaveLen(3))
+	})
+
+	It("should only send an alert again to the sinks that failed", func() {
+		mails := 0
+		sendMail = func(string, smtp.Auth, string, []string, []byte) error {
+			mails++
+			if mails == 1 {
+				return errors.New("mail server unavailable")
+			}
+			return nil
+		}
+		DeferCleanup(func() { sendMail = smtp.SendMail })
+
+		var policy linuxfestv2025.NotificationPolicy
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "team-a"}, &policy)).To(Succeed())
+		policy.Spec.SMTP = &linuxfestv2025.SMTPSink{Host: "mail", Port: 587, From: "pets@example.com", To: []string{"a@example.com"}}
+		Expect(c.Update(ctx, &policy)).To(Succeed())
+
+		Expect(notifier.Deliver(ctx, alertAt("NeedFood", now))).NotTo(Succeed())
+		Expect(recv.Alerts()).To(HaveLen(1))
+		Expect(mails).To(Equal(1))
+
+		By("retrying the mail without paging the webhook again")
+		Expect(notifier.Deliver(ctx, alertAt("NeedFood", now.Add(10*time.Second)))).To(Succeed())
+		Expect(recv.Alerts()).To(HaveLen(1))
+		Expect(mails).To(Equal(2))
+
+		By("muting both sinks within the repeat interval")
+		Expect(notifier.Deliver(ctx, alertAt("NeedFood", now.Add(20*time.Second)))).To(Succeed())
+		Expect(recv.Alerts()).To(HaveLen(1))
+		Expect(mails).To(Equal(2))
+
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "team-a"}, &policy)).To(Succeed())
+		Expect(policy.Status.Failed).To(Equal(1))
+		Expect(policy.Status.Sent).To(Equal(1))
+	})
+
+	It("should skip reasons the policy does not cover", func() {
+		Expect(notifier.Deliver(ctx, alertAt("NeedLove", now))).To(Succeed())
+		Expect(recv.Alerts()).To(BeEmpty())
+	})
+
+	It("should skip pets outside the selector", func() {
+		var pet linuxfestv2025.Pet
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "barky"}, &pet)).To(Succeed())
+		pet.Labels["team"] = "b"
+		Expect(c.Update(ctx, &pet)).To(Succeed())
+
+		Expect(notifier.Deliver(ctx, alertAt("NeedFood", now))).To(Succeed())
+		Expect(recv.Alerts()).To(BeEmpty())
+	})
+
+	It("should forget sent alerts once their repeat interval has passed", func() {
+		Expect(notifier.Deliver(ctx, alertAt("NeedFood", now))).To(Succeed())
+		Expect(notifier.sent).To(HaveLen(1))
+
+		Expect(notifier.Deliver(ctx, alertAt("Dead", now.Add(2*time.Hour)))).To(Succeed())
+		Expect(notifier.sent).To(HaveLen(1))
+		Expect(notifier.sent).To(HaveKey("policy-uid/barky/Dead/webhook/" + recv.URL))
+	})
+
+	It("should deliver queued alerts in the background", func() {
+		runCtx, cancel := context.WithCancel(ctx)
+		DeferCleanup(cancel)
+		go func() { _ = notifier.Start(runCtx) }()
+
+		var pet linuxfestv2025.Pet
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "barky"}, &pet)).To(Succeed())
+		notifier.Notify(ctx, &pet, "Dead", "Barky died")
+
+		Eventually(recv.Alerts).Should(ContainElement(HaveField("Message", "Barky died")))
+	})
+
+	It("should not let a slow sink hold up other policies", func() {
+		release := make(chan struct{})
+		slow := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { <-release }))
+		DeferCleanup(slow.Close)
+		DeferCleanup(func() { close(release) })
+
+		Expect(c.Create(ctx, &linuxfestv2025.NotificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "slow", Namespace: "default", UID: "slow-uid"},
+			Spec:       linuxfestv2025.NotificationPolicySpec{Webhooks: []linuxfestv2025.WebhookSink{{URL: slow.URL}}},
+		})).To(Succeed())
+
+		runCtx, cancel := context.WithCancel(ctx)
+		DeferCleanup(cancel)
+		go func() { _ = notifier.Start(runCtx) }()
+
+		var pet linuxfestv2025.Pet
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "barky"}, &pet)).To(Succeed())
+		notifier.Notify(ctx, &pet, "Dead", "Barky died")
+
+		Eventually(recv.Alerts).Should(ContainElement(HaveField("Message", "Barky died")))
+	})
+})
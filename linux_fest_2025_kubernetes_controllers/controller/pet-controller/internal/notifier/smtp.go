@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// sendMail is replaced in tests.
+var sendMail = smtp.SendMail
+
+// SMTPSink emails alerts through an SMTP server.
+type SMTPSink struct {
+	// Addr is the host:port of the server.
+	Addr string
+
+	// Host is used to authenticate against the server.
+	Host string
+
+	From string
+	To   []string
+
+	// Username and Password enable PLAIN authentication when set.
+	Username string
+	Password string
+}
+
+// Send emails alert to every recipient.
+func (s *SMTPSink) Send(_ context.Context, alert Alert) error {
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", s.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&msg, "Subject: [pet-controller] %s/%s: %s\r\n", alert.Namespace, alert.Name, alert.Reason)
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s\r\n\r\n", alert.Message)
+	fmt.Fprintf(&msg, "Food: %d\r\nLove: %d\r\nTime: %s\r\n", alert.Food, alert.Love, alert.Time.Format(time.RFC1123))
+
+	if err := sendMail(s.Addr, auth, s.From, s.To, []byte(msg.String())); err != nil {
+		return fmt.Errorf("smtp %s: %w", s.Addr, err)
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/config"
+)
+
+// Runner runs scenarios against a running pet controller.
+type Runner struct {
+	client.Client
+
+	// Clock is the clock of the pet controller.
+	Clock *clocktesting.FakeClock
+
+	// Events returns every event the pet controller recorded so far, formatted
+	// as "<type> <reason> <message>".
+	Events func() []string
+
+	// Namespace is where the pets are created.
+	Namespace string
+
+	// Annotations are the annotation keys the pet controller acts on, the
+	// default keys are used when empty.
+	Annotations config.AnnotationConfig
+
+	// Timeout is how long the runner waits for the controller to catch up.
+	Timeout time.Duration
+
+	// Polling is how often the runner checks whether the controller caught up.
+	Polling time.Duration
+}
+
+// run is the state of a single scenario run.
+type run struct {
+	*Runner
+	pets map[string]*linuxfestv2025.Pet
+
+	// events is the number of events recorded before the scenario started.
+	events int
+}
+
+// Run runs s and reports the first step that failed. The pets of s are deleted afterwards.
+func (r *Runner) Run(ctx context.Context, s *Scenario) error {
+	if r.Annotations == (config.AnnotationConfig{}) {
+		r.Annotations = config.Default().Annotations
+	}
+	if r.Timeout == 0 {
+		r.Timeout = 10 * time.Second
+	}
+	if r.Polling == 0 {
+		r.Polling = 100 * time.Millisecond
+	}
+
+	run := &run{Runner: r, pets: map[string]*linuxfestv2025.Pet{}, events: len(r.Events())}
+	defer run.cleanup(context.WithoutCancel(ctx))
+
+	for i, pet := range s.Pets {
+		if err := run.create(ctx, pet); err != nil {
+			return fmt.Errorf("%s: pets[%d]: %w", s.Name, i, err)
+		}
+	}
+
+	for i, step := range s.Steps {
+		var err error
+		switch {
+		case step.Advance != nil:
+			err = run.advance(ctx, step.Advance.Duration)
+		case step.Feed != nil:
+			err = run.act(ctx, r.Annotations.Feed, step.Feed)
+		case step.Pet != nil:
+			err = run.act(ctx, r.Annotations.Pet, step.Pet)
+		case step.Expect != nil:
+			err = run.expect(ctx, step.Expect)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: steps[%d]: %w", s.Name, i, err)
+		}
+	}
+	return nil
+}
+
+// create creates pet and waits for it to be initialized.
+func (r *run) create(ctx context.Context, pet Pet) error {
+	obj := &linuxfestv2025.Pet{
+		ObjectMeta: metav1.ObjectMeta{Name: pet.Name, Namespace: r.Namespace},
+		Spec:       pet.Spec,
+	}
+	if err := r.Create(ctx, obj); err != nil {
+		return err
+	}
+	r.pets[pet.Name] = obj
+
+	return r.poll(ctx, func(ctx context.Context) error {
+		latest, err := r.latest(ctx, pet.Name)
+		if err != nil {
+			return err
+		}
+		if !latest.Status.Initialized {
+			return fmt.Errorf("pet %s is not initialized", pet.Name)
+		}
+		return nil
+	})
+}
+
+// advance steps the clock by d, at most one decay interval of the pet that
+// decays most often at a time, and waits for every pet to catch up after each step.
+func (r *run) advance(ctx context.Context, d time.Duration) error {
+	step := d
+	for _, pet := range r.pets {
+		step = min(step, pet.Spec.DecayInterval.Duration)
+	}
+
+	for remaining := d; remaining > 0; remaining -= step {
+		r.Clock.Step(min(step, remaining))
+		if err := r.poll(ctx, r.settled); err != nil {
+			return fmt.Errorf("advancing to %s: %w", r.Clock.Now().Format(time.RFC3339), err)
+		}
+	}
+	return nil
+}
+
+// settled reports an error for the first living pet that is due a decay.
+func (r *run) settled(ctx context.Context) error {
+	for _, name := range sortedKeys(r.pets) {
+		latest, err := r.latest(ctx, name)
+		if err != nil {
+			return err
+		}
+		if meta.IsStatusConditionTrue(latest.Status.Conditions, linuxfestv2025.PetConditionDead) {
+			continue
+		}
+		if r.Clock.Since(latest.Status.ModifiedTime.Time) >= latest.Spec.DecayInterval.Duration {
+			return fmt.Errorf("pet %s did not decay since %s", name, latest.Status.ModifiedTime.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// act sets the annotation key of the pet of action and waits for the controller to consume it.
+func (r *run) act(ctx context.Context, key string, action *Action) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := r.latest(ctx, action.Pet)
+		if err != nil {
+			return err
+		}
+		if latest.Annotations == nil {
+			latest.Annotations = map[string]string{}
+		}
+		latest.Annotations[key] = strconv.Itoa(action.Amount)
+		return r.Update(ctx, latest)
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.poll(ctx, func(ctx context.Context) error {
+		latest, err := r.latest(ctx, action.Pet)
+		if err != nil {
+			return err
+		}
+		if _, ok := latest.Annotations[key]; ok {
+			return fmt.Errorf("annotation %s of pet %s was not consumed", key, action.Pet)
+		}
+		return nil
+	})
+}
+
+// expect waits for the pet of want to match it.
+func (r *run) expect(ctx context.Context, want *Expectation) error {
+	return r.poll(ctx, func(ctx context.Context) error {
+		latest, err := r.latest(ctx, want.Pet)
+		if err != nil {
+			return err
+		}
+		return errors.Join(
+			matchStatus(latest.Status, want.Status),
+			matchConditions(latest.Status.Conditions, want.Conditions),
+			matchEvents(r.Events()[r.events:], latest.Spec.Nickname, want.Events),
+		)
+	})
+}
+
+// poll calls check until it succeeds and returns its last error on timeout.
+func (r *run) poll(ctx context.Context, check func(context.Context) error) error {
+	var last error
+	err := wait.PollUntilContextTimeout(ctx, r.Polling, r.Timeout, true, func(ctx context.Context) (bool, error) {
+		last = check(ctx)
+		return last == nil, nil
+	})
+	if err != nil && last != nil {
+		return last
+	}
+	return err
+}
+
+func (r *run) latest(ctx context.Context, name string) (*linuxfestv2025.Pet, error) {
+	var pet linuxfestv2025.Pet
+	if err := r.Get(ctx, client.ObjectKey{Namespace: r.Namespace, Name: name}, &pet); err != nil {
+		return nil, err
+	}
+	return &pet, nil
+}
+
+func (r *run) cleanup(ctx context.Context) {
+	for _, pet := range r.pets {
+		_ = client.IgnoreNotFound(r.Delete(ctx, pet))
+	}
+}
+
+// matchStatus compares the fields of status listed in want by their JSON names.
+// Fields that are left out of the status because they are empty match their zero value.
+func matchStatus(status linuxfestv2025.PetStatus, want map[string]any) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, key := range sortedKeys(want) {
+		actual, ok := got[key]
+		if !ok {
+			actual = reflect.Zero(reflect.TypeOf(want[key])).Interface()
+		}
+		if !reflect.DeepEqual(actual, want[key]) {
+			errs = append(errs, fmt.Errorf("status.%s is %v, expected %v", key, actual, want[key]))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// matchConditions compares the status of the condition types listed in want.
+func matchConditions(conditions []metav1.Condition, want map[string]metav1.ConditionStatus) error {
+	var errs []error
+	for _, conditionType := range sortedKeys(want) {
+		actual := metav1.ConditionUnknown
+		if condition := meta.FindStatusCondition(conditions, conditionType); condition != nil {
+			actual = condition.Status
+		}
+		if actual != want[conditionType] {
+			errs = append(errs, fmt.Errorf("condition %s is %s, expected %s", conditionType, actual, want[conditionType]))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// matchEvents checks that the events mentioning nickname include every reason
+// in want, as often as it is listed.
+func matchEvents(events []string, nickname string, want []string) error {
+	got := map[string]int{}
+	for _, event := range events {
+		fields := strings.Fields(event)
+		if len(fields) < 2 || !strings.Contains(event, nickname+" ") {
+			continue
+		}
+		got[fields[1]]++
+	}
+
+	expected := map[string]int{}
+	for _, reason := range want {
+		expected[reason]++
+	}
+
+	var errs []error
+	for _, reason := range sortedKeys(expected) {
+		if got[reason] < expected[reason] {
+			errs = append(errs, fmt.Errorf("%d %s events recorded for %s, expected %d", got[reason], reason, nickname, expected[reason]))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scenario runs declarative gameplay regression cases against a pet
+// controller. A scenario creates pets, advances the controller's clock, feeds
+// and pets them and asserts their status, conditions and events, so new cases
+// need YAML instead of Go.
+package scenario
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+)
+
+// Scenario is a gameplay regression case.
+type Scenario struct {
+	// Name describes the scenario.
+	Name string `json:"name"`
+
+	// Pets are created before the first step and deleted after the last.
+	Pets []Pet `json:"pets"`
+
+	// Steps are run in order.
+	Steps []Step `json:"steps"`
+}
+
+// Pet is a pet created by a scenario.
+type Pet struct {
+	Name string                 `json:"name"`
+	Spec linuxfestv2025.PetSpec `json:"spec"`
+}
+
+// Step is a single step of a scenario, exactly one of its fields is set.
+type Step struct {
+	// Advance steps the clock forward one decay interval at a time and waits
+	// for the pets to decay after every step.
+	Advance *metav1.Duration `json:"advance,omitempty"`
+
+	// Feed feeds a pet.
+	Feed *Action `json:"feed,omitempty"`
+
+	// Pet pets a pet.
+	Pet *Action `json:"pet,omitempty"`
+
+	// Expect waits for a pet to match.
+	Expect *Expectation `json:"expect,omitempty"`
+}
+
+// Action gives a pet food or love.
+type Action struct {
+	Pet    string `json:"pet"`
+	Amount int    `json:"amount"`
+}
+
+// Expectation is the state a pet is expected to reach.
+type Expectation struct {
+	Pet string `json:"pet"`
+
+	// Status are fields of the pet's status by their JSON names and their
+	// expected values. Fields that are not listed are not checked.
+	Status map[string]any `json:"status,omitempty"`
+
+	// Conditions are condition types and their expected status.
+	Conditions map[string]metav1.ConditionStatus `json:"conditions,omitempty"`
+
+	// Events are the reasons of events the pet is expected to have had since
+	// the scenario started. Listing a reason twice expects it at least twice.
+	Events []string `json:"events,omitempty"`
+}
+
+// Load reads and validates the scenario at path.
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var s Scenario
+	if err := yaml.UnmarshalStrict(data, &s); err != nil {
+		return nil, fmt.Errorf("decoding scenario %s: %w", path, err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Validate reports everything that is wrong with s.
+func (s *Scenario) Validate() error {
+	var errs field.ErrorList
+	if s.Name == "" {
+		errs = append(errs, field.Required(field.NewPath("name"), ""))
+	}
+
+	var names []string
+	for i, pet := range s.Pets {
+		path := field.NewPath("pets").Index(i)
+		switch {
+		case pet.Name == "":
+			errs = append(errs, field.Required(path.Child("name"), ""))
+		case slices.Contains(names, pet.Name):
+			errs = append(errs, field.Duplicate(path.Child("name"), pet.Name))
+		}
+		if pet.Spec.Nickname == "" {
+			errs = append(errs, field.Required(path.Child("spec", "nickname"), ""))
+		}
+		names = append(names, pet.Name)
+	}
+
+	known := func(path *field.Path, name string) {
+		if !slices.Contains(names, name) {
+			errs = append(errs, field.NotFound(path, name))
+		}
+	}
+	for i, step := range s.Steps {
+		path := field.NewPath("steps").Index(i)
+
+		set := 0
+		if step.Advance != nil {
+			set++
+			if step.Advance.Duration <= 0 {
+				errs = append(errs, field.Invalid(path.Child("advance"), step.Advance.Duration.String(), "must be positive"))
+			}
+		}
+		for _, a := range []struct {
+			name   string
+			action *Action
+		}{{"feed", step.Feed}, {"pet", step.Pet}} {
+			if a.action == nil {
+				continue
+			}
+			set++
+			known(path.Child(a.name, "pet"), a.action.Pet)
+			if a.action.Amount <= 0 {
+				errs = append(errs, field.Invalid(path.Child(a.name, "amount"), a.action.Amount, "must be positive"))
+			}
+		}
+		if step.Expect != nil {
+			set++
+			known(path.Child("expect", "pet"), step.Expect.Pet)
+		}
+		if set != 1 {
+			errs = append(errs, field.Invalid(path, set, "exactly one of advance, feed, pet or expect must be set"))
+		}
+	}
+
+	return errs.ToAggregate()
+}
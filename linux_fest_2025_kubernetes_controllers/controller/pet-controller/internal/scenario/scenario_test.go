@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scenario
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+)
+
+var _ = Describe("Load", func() {
+	load := func(data string) (*Scenario, error) {
+		path := filepath.Join(GinkgoT().TempDir(), "scenario.yaml")
+		Expect(os.WriteFile(path, []byte(data), 0o600)).To(Succeed())
+		return Load(path)
+	}
+
+	It("should load a valid scenario", func() {
+		s, err := load(`name: feeding
+pets:
+  - name: rex
+    spec:
+      nickname: Rex
+      decayInterval: 1s
+steps:
+  - advance: 2s
+  - feed: {pet: rex, amount: 10}
+  - expect:
+      pet: rex
+      status: {food: 90, stage: Baby}
+      conditions: {Hungry: "False"}
+      events: [Fed]
+`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Pets).To(HaveLen(1))
+		Expect(s.Steps).To(HaveLen(3))
+		Expect(s.Steps[1].Feed).To(Equal(&Action{Pet: "rex", Amount: 10}))
+		Expect(s.Steps[2].Expect.Status).To(Equal(map[string]any{"food": float64(90), "stage": "Baby"}))
+	})
+
+	It("should reject unknown fields", func() {
+		_, err := load("name: typo\nstepz: []\n")
+		Expect(err).To(MatchError(ContainSubstring("stepz")))
+	})
+
+	It("should report every invalid step", func() {
+		_, err := load(`name: broken
+pets:
+  - name: rex
+    spec: {nickname: Rex}
+  - name: rex
+    spec: {nickname: Rex}
+steps:
+  - advance: 0s
+  - feed: {pet: fido, amount: 0}
+  - {}
+  - advance: 1s
+    expect: {pet: rex}
+`)
+		Expect(err).To(MatchError(SatisfyAll(
+			ContainSubstring("pets[1].name: Duplicate value"),
+			ContainSubstring("steps[0].advance"),
+			ContainSubstring(`steps[1].feed.pet: Not found: "fido"`),
+			ContainSubstring("steps[1].feed.amount"),
+			ContainSubstring("steps[2]: Invalid value: 0"),
+			ContainSubstring("steps[3]: Invalid value: 2"),
+		)))
+	})
+})
+
+var _ = Describe("matchers", func() {
+	It("should compare listed status fields and treat left out fields as zero", func() {
+		status := linuxfestv2025.PetStatus{Food: 90, Stage: linuxfestv2025.PetStageBaby}
+		Expect(matchStatus(status, map[string]any{"food": float64(90), "love": float64(0), "stage": "Baby"})).To(Succeed())
+		Expect(matchStatus(status, map[string]any{"food": float64(80)})).To(MatchError("status.food is 90, expected 80"))
+	})
+
+	It("should treat missing conditions as unknown", func() {
+		conditions := []metav1.Condition{{Type: "Hungry", Status: metav1.ConditionTrue}}
+		Expect(matchConditions(conditions, map[string]metav1.ConditionStatus{"Hungry": metav1.ConditionTrue})).To(Succeed())
+		Expect(matchConditions(conditions, map[string]metav1.ConditionStatus{"Dead": metav1.ConditionFalse})).
+			To(MatchError("condition Dead is Unknown, expected False"))
+	})
+
+	It("should count the events of a pet", func() {
+		events := []string{
+			"Normal Fed 🍗 Rex was fed, food 50 → 60",
+			"Normal Fed 🍗 Rexy was fed, food 50 → 60",
+			"Warning NeedFood 😭Rex Needs Food",
+		}
+		Expect(matchEvents(events, "Rex", []string{"Fed", "NeedFood"})).To(Succeed())
+		Expect(matchEvents(events, "Rex", []string{"Fed", "Fed"})).
+			To(MatchError("1 Fed events recorded for Rex, expected 2"))
+	})
+})
@@ -0,0 +1,462 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sharding splits the pets between several controller replicas. Every
+// replica renews a member Lease, the shards are assigned to the live members by
+// rendezvous hashing and a replica only reconciles the pets of the shards whose
+// Lease it holds. When a replica joins or leaves, the shards move to their new
+// owners: the old owner releases the shard Lease and the new one acquires it.
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+)
+
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
+
+// Label holds the shard of a pet. It is set by the replica owning the shard.
+const Label = "linuxfest.example.com/shard"
+
+// GroupLabel is set on the member Leases of the replicas sharing the pets.
+const GroupLabel = "linuxfest.example.com/shard-group"
+
+// Options configures a Coordinator.
+type Options struct {
+	// Namespace holds the member and shard Leases.
+	Namespace string
+
+	// Group names the Leases, replicas with the same group share the pets.
+	Group string
+
+	// Identity is the unique name of this replica, usually its pod name.
+	Identity string
+
+	// Shards is the number of shards the pets are split into.
+	Shards int
+
+	// LeaseDuration is how long a member or shard Lease is valid without being renewed, 15s when zero.
+	LeaseDuration time.Duration
+
+	// RenewInterval is how often the Leases are renewed and the shards rebalanced, 5s when zero.
+	RenewInterval time.Duration
+}
+
+// Coordinator claims the shards of this replica and tells which pets it owns.
+type Coordinator struct {
+	client client.Client
+	reader client.Reader
+	opts   Options
+
+	// events receives the pets of newly acquired shards so they are reconciled.
+	events chan event.GenericEvent
+
+	mu sync.RWMutex
+	// owned holds when the Lease of every owned shard expires.
+	owned map[int]time.Time
+
+	now func() time.Time
+}
+
+// NewCoordinator returns a Coordinator writing Leases with c and reading them
+// with reader, which should not be cached.
+func NewCoordinator(c client.Client, reader client.Reader, opts Options) (*Coordinator, error) {
+	if opts.Shards <= 0 {
+		return nil, fmt.Errorf("the number of shards must be positive, got %d", opts.Shards)
+	}
+	if opts.Namespace == "" || opts.Group == "" || opts.Identity == "" {
+		return nil, errors.New("sharding needs a namespace, a group and an identity")
+	}
+	if opts.LeaseDuration <= 0 {
+		opts.LeaseDuration = 15 * time.Second
+	}
+	if opts.RenewInterval <= 0 {
+		opts.RenewInterval = 5 * time.Second
+	}
+	if opts.RenewInterval >= opts.LeaseDuration {
+		return nil, fmt.Errorf("the renew interval %s must be shorter than the lease duration %s",
+			opts.RenewInterval, opts.LeaseDuration)
+	}
+
+	return &Coordinator{
+		client: c,
+		reader: reader,
+		opts:   opts,
+		events: make(chan event.GenericEvent, 1000),
+		owned:  map[int]time.Time{},
+		now:    time.Now,
+	}, nil
+}
+
+// ShardOf returns the shard of the pet with key when the pets are split into shards.
+func ShardOf(key types.NamespacedName, shards int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key.String()))
+	return int(h.Sum32() % uint32(shards))
+}
+
+// Shard returns the shard of the pet with key.
+func (c *Coordinator) Shard(key types.NamespacedName) int {
+	return ShardOf(key, c.opts.Shards)
+}
+
+// Owns reports whether this replica holds the shard of the pet with key.
+func (c *Coordinator) Owns(key types.NamespacedName) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	expires, ok := c.owned[c.Shard(key)]
+	return ok && c.now().Before(expires)
+}
+
+// Owned returns the shards this replica holds, in order.
+func (c *Coordinator) Owned() []int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	now := c.now()
+	var shards []int
+	for shard, expires := range c.owned {
+		if now.Before(expires) {
+			shards = append(shards, shard)
+		}
+	}
+	slices.Sort(shards)
+	return shards
+}
+
+// Events returns the channel the pets of newly acquired shards are sent to.
+func (c *Coordinator) Events() <-chan event.GenericEvent {
+	return c.events
+}
+
+// NeedLeaderElection is false, every replica owns its own shards.
+func (c *Coordinator) NeedLeaderElection() bool {
+	return false
+}
+
+// Start keeps the Leases of this replica renewed until ctx is cancelled, then
+// gives its shards up so the other replicas take them over straight away.
+func (c *Coordinator) Start(ctx context.Context) error {
+	log := log.FromContext(ctx).WithName("sharding").WithValues("identity", c.opts.Identity)
+
+	ticker := time.NewTicker(c.opts.RenewInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Rebalance(ctx); err != nil {
+			log.Error(err, "unable to rebalance shards")
+		}
+
+		select {
+		case <-ctx.Done():
+			// 👋 Leave the group while the API server is still reachable
+			leaveCtx, cancel := context.WithTimeout(context.Background(), c.opts.RenewInterval)
+			defer cancel()
+			if err := c.leave(leaveCtx); err != nil {
+				log.Error(err, "unable to release shards")
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Rebalance renews the member Lease of this replica, releases the shards that
+// belong to another live member now and acquires the free shards assigned to it.
+func (c *Coordinator) Rebalance(ctx context.Context) error {
+	if err := c.renewMember(ctx); err != nil {
+		return fmt.Errorf("renewing member lease: %w", err)
+	}
+
+	members, err := c.members(ctx)
+	if err != nil {
+		return fmt.Errorf("listing members: %w", err)
+	}
+
+	var (
+		errs     []error
+		acquired []int
+	)
+	for shard := range c.opts.Shards {
+		owner := assign(shard, members)
+		held, err := c.claim(ctx, shard, owner == c.opts.Identity, members)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("shard %d: %w", shard, err))
+			// ⌛ Keep a shard until its Lease expires when it can't be renewed
+			continue
+		}
+
+		c.mu.Lock()
+		_, had := c.owned[shard]
+		if held {
+			c.owned[shard] = c.now().Add(c.opts.LeaseDuration)
+		} else {
+			delete(c.owned, shard)
+		}
+		c.mu.Unlock()
+
+		if held && !had {
+			acquired = append(acquired, shard)
+		}
+	}
+	ownedShards.Set(float64(len(c.Owned())))
+
+	if len(acquired) > 0 {
+		log := log.FromContext(ctx)
+		log.Info("Acquired shards", "shards", acquired, "members", members)
+
+		// 📬 Don't hold up the renewals while the controller catches up with the pets
+		go func() {
+			if err := c.enqueue(ctx, acquired); err != nil {
+				log.Error(err, "unable to reconcile the pets of acquired shards", "shards", acquired)
+			}
+		}()
+	}
+	return errors.Join(errs...)
+}
+
+// renewMember creates or renews the member Lease of this replica.
+func (c *Coordinator) renewMember(ctx context.Context) error {
+	now := metav1.NewMicroTime(c.now())
+
+	var lease coordinationv1.Lease
+	err := c.reader.Get(ctx, client.ObjectKey{Namespace: c.opts.Namespace, Name: c.memberName()}, &lease)
+	if apierrors.IsNotFound(err) {
+		lease = coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: c.opts.Namespace,
+				Name:      c.memberName(),
+				Labels:    map[string]string{GroupLabel: c.opts.Group},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(c.opts.Identity),
+				LeaseDurationSeconds: ptr.To(int32(c.opts.LeaseDuration.Seconds())),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		return c.client.Create(ctx, &lease)
+	} else if err != nil {
+		return err
+	}
+
+	lease.Spec.RenewTime = &now
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(c.opts.LeaseDuration.Seconds()))
+	return c.client.Update(ctx, &lease)
+}
+
+// members returns the identities of the live members of the group, sorted.
+func (c *Coordinator) members(ctx context.Context) ([]string, error) {
+	var leases coordinationv1.LeaseList
+	if err := c.reader.List(ctx, &leases, client.InNamespace(c.opts.Namespace),
+		client.MatchingLabels{GroupLabel: c.opts.Group}); err != nil {
+		return nil, err
+	}
+
+	var members []string
+	for i := range leases.Items {
+		lease := &leases.Items[i]
+		if lease.Spec.HolderIdentity != nil && !c.expired(lease) {
+			members = append(members, *lease.Spec.HolderIdentity)
+		}
+	}
+	slices.Sort(members)
+	return members, nil
+}
+
+// claim renews, acquires or releases the Lease of shard and reports whether
+// this replica holds it afterwards. A shard is only acquired once it is free,
+// expired or held by a replica that left, so two replicas never hold it at once.
+func (c *Coordinator) claim(ctx context.Context, shard int, assigned bool, members []string) (bool, error) {
+	now := metav1.NewMicroTime(c.now())
+
+	var lease coordinationv1.Lease
+	err := c.reader.Get(ctx, client.ObjectKey{Namespace: c.opts.Namespace, Name: c.shardName(shard)}, &lease)
+	if apierrors.IsNotFound(err) {
+		if !assigned {
+			return false, nil
+		}
+		lease = coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: c.opts.Namespace, Name: c.shardName(shard)},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(c.opts.Identity),
+				LeaseDurationSeconds: ptr.To(int32(c.opts.LeaseDuration.Seconds())),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		if err := c.client.Create(ctx, &lease); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				// 🏃 Another replica was faster
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	holder := ptr.Deref(lease.Spec.HolderIdentity, "")
+	switch {
+	case holder == c.opts.Identity && assigned:
+		// 🔁 Renew
+		lease.Spec.RenewTime = &now
+	case holder == c.opts.Identity:
+		// 🤝 Hand the shard over to the member it is assigned to now
+		lease.Spec.HolderIdentity = nil
+		lease.Spec.AcquireTime = nil
+		lease.Spec.RenewTime = nil
+		return false, ignoreConflict(c.client.Update(ctx, &lease))
+	case assigned && (holder == "" || c.expired(&lease) || !slices.Contains(members, holder)):
+		// 🏴 Acquire
+		lease.Spec.HolderIdentity = ptr.To(c.opts.Identity)
+		lease.Spec.AcquireTime = &now
+		lease.Spec.RenewTime = &now
+		lease.Spec.LeaseTransitions = ptr.To(ptr.Deref(lease.Spec.LeaseTransitions, 0) + 1)
+	default:
+		return false, nil
+	}
+
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(c.opts.LeaseDuration.Seconds()))
+	if err := c.client.Update(ctx, &lease); err != nil {
+		if apierrors.IsConflict(err) && holder != c.opts.Identity {
+			// 🏃 Another replica was faster
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// leave releases every shard of this replica and deletes its member Lease.
+func (c *Coordinator) leave(ctx context.Context) error {
+	var errs []error
+	for _, shard := range c.Owned() {
+		var lease coordinationv1.Lease
+		if err := c.reader.Get(ctx, client.ObjectKey{Namespace: c.opts.Namespace, Name: c.shardName(shard)}, &lease); err != nil {
+			errs = append(errs, client.IgnoreNotFound(err))
+			continue
+		}
+		if ptr.Deref(lease.Spec.HolderIdentity, "") != c.opts.Identity {
+			continue
+		}
+		lease.Spec.HolderIdentity = nil
+		lease.Spec.AcquireTime = nil
+		lease.Spec.RenewTime = nil
+		errs = append(errs, ignoreConflict(c.client.Update(ctx, &lease)))
+	}
+
+	c.mu.Lock()
+	c.owned = map[int]time.Time{}
+	c.mu.Unlock()
+	ownedShards.Set(0)
+
+	member := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Namespace: c.opts.Namespace, Name: c.memberName()}}
+	errs = append(errs, client.IgnoreNotFound(c.client.Delete(ctx, member)))
+	return errors.Join(errs...)
+}
+
+// enqueue sends the pets of shards to the reconciler.
+func (c *Coordinator) enqueue(ctx context.Context, shards []int) error {
+	var pets linuxfestv2025.PetList
+	if err := c.client.List(ctx, &pets); err != nil {
+		return fmt.Errorf("listing pets of acquired shards: %w", err)
+	}
+
+	for i := range pets.Items {
+		pet := &pets.Items[i]
+		if !slices.Contains(shards, c.Shard(client.ObjectKeyFromObject(pet))) {
+			continue
+		}
+		select {
+		case c.events <- event.GenericEvent{Object: pet}:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	return nil
+}
+
+// expired reports whether lease was not renewed within its duration.
+func (c *Coordinator) expired(lease *coordinationv1.Lease) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	duration := time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	return !c.now().Before(lease.Spec.RenewTime.Add(duration))
+}
+
+func (c *Coordinator) memberName() string {
+	return c.opts.Group + "-member-" + c.opts.Identity
+}
+
+func (c *Coordinator) shardName(shard int) string {
+	return c.opts.Group + "-shard-" + strconv.Itoa(shard)
+}
+
+// assign returns the member shard belongs to. Rendezvous hashing only moves the
+// shards of a member that joins or leaves.
+func assign(shard int, members []string) string {
+	var (
+		owner string
+		best  uint64
+	)
+	for _, member := range members {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(member + "/" + strconv.Itoa(shard)))
+		if score := mix(h.Sum64()); owner == "" || score > best {
+			owner, best = member, score
+		}
+	}
+	return owner
+}
+
+// mix spreads the bits of h, FNV alone barely tells apart keys that only differ
+// in their last bytes.
+func mix(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+func ignoreConflict(err error) error {
+	if apierrors.IsConflict(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/config"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/controller"
+	"github.com/itzloop/pet-controller/internal/sharding"
+)
+
+const shards = 8
+
+// replica is a manager running a sharded pet controller.
+type replica struct {
+	coordinator *sharding.Coordinator
+	stop        context.CancelFunc
+	done        chan struct{}
+}
+
+// startReplica starts a manager reconciling the pets of the shards it holds.
+func startReplica(identity string) *replica {
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  scheme.Scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+		// 👯 Every replica runs a controller named pet in this process
+		Controller: ctrlconfig.Controller{SkipNameValidation: ptr.To(true)},
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	coordinator, err := sharding.NewCoordinator(mgr.GetClient(), mgr.GetAPIReader(), sharding.Options{
+		Namespace:     "default",
+		Group:         "pets",
+		Identity:      identity,
+		Shards:        shards,
+		LeaseDuration: 2 * time.Second,
+		RenewInterval: 200 * time.Millisecond,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(mgr.Add(coordinator)).To(Succeed())
+
+	Expect((&controller.PetReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: &record.FakeRecorder{},
+		Shards:   coordinator,
+	}).SetupWithManager(mgr)).To(Succeed())
+
+	replicaCtx, stop := context.WithCancel(ctx)
+	r := &replica{coordinator: coordinator, stop: stop, done: make(chan struct{})}
+	go func() {
+		defer GinkgoRecover()
+		defer close(r.done)
+		Expect(mgr.Start(replicaCtx)).To(Succeed())
+	}()
+	return r
+}
+
+// held returns the shards held by replicas, failing when two hold the same shard.
+func held(replicas ...*replica) func(Gomega) []int {
+	return func(g Gomega) []int {
+		owners := map[int]int{}
+		var all []int
+		for i, r := range replicas {
+			for _, shard := range r.coordinator.Owned() {
+				owner, taken := owners[shard]
+				g.Expect(taken).To(BeFalse(), "shard %d is held by replicas %d and %d", shard, owner, i)
+				owners[shard] = i
+				all = append(all, shard)
+			}
+		}
+		return all
+	}
+}
+
+var _ = Describe("Sharding", func() {
+	every := make([]int, shards)
+	for i := range every {
+		every[i] = i
+	}
+
+	It("should assign every pet to one shard", func() {
+		key := types.NamespacedName{Namespace: "default", Name: "rex"}
+		Expect(sharding.ShardOf(key, shards)).To(Equal(sharding.ShardOf(key, shards)))
+		Expect(sharding.ShardOf(key, shards)).To(BeNumerically("<", shards))
+	})
+
+	It("should split the pets between replicas and rebalance when they join and leave", func() {
+		By("starting one replica")
+		first := startReplica("first")
+		DeferCleanup(func() { first.stop(); <-first.done })
+
+		Eventually(held(first)).WithTimeout(10 * time.Second).Should(ConsistOf(every))
+
+		By("creating pets")
+		var names []string
+		for i := range 20 {
+			name := fmt.Sprintf("sharded-%d", i)
+			names = append(names, name)
+			Expect(k8sClient.Create(ctx, &linuxfestv2025.Pet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+				Spec:       linuxfestv2025.PetSpec{Nickname: name},
+			})).To(Succeed())
+		}
+		DeferCleanup(func() {
+			for _, name := range names {
+				pet := &linuxfestv2025.Pet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, pet))).To(Succeed())
+			}
+		})
+
+		By("labeling every pet with its shard")
+		Eventually(func(g Gomega) {
+			for _, name := range names {
+				var pet linuxfestv2025.Pet
+				key := types.NamespacedName{Namespace: "default", Name: name}
+				g.Expect(k8sClient.Get(ctx, key, &pet)).To(Succeed())
+				g.Expect(pet.Labels).To(HaveKeyWithValue(sharding.Label, strconv.Itoa(sharding.ShardOf(key, shards))))
+				g.Expect(pet.Status.Initialized).To(BeTrue())
+			}
+		}).WithTimeout(10 * time.Second).Should(Succeed())
+
+		By("starting two more replicas")
+		second, third := startReplica("second"), startReplica("third")
+		DeferCleanup(func() { second.stop(); <-second.done })
+
+		Eventually(held(first, second, third)).WithTimeout(10 * time.Second).Should(ConsistOf(every))
+		Eventually(func() [][]int {
+			return [][]int{first.coordinator.Owned(), second.coordinator.Owned(), third.coordinator.Owned()}
+		}).WithTimeout(10 * time.Second).ShouldNot(ContainElement(BeEmpty()))
+		Consistently(held(first, second, third)).WithTimeout(time.Second).Should(ConsistOf(every))
+
+		By("stopping a replica")
+		third.stop()
+		<-third.done
+		Eventually(held(first, second)).WithTimeout(10 * time.Second).Should(ConsistOf(every))
+	})
+})
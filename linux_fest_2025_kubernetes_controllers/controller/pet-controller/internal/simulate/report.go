@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulate
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/itzloop/pet-controller/pkg/engine"
+)
+
+// levels are the bars of the chart, from empty to full.
+var levels = []rune(" ▁▂▃▄▅▆▇█")
+
+// ReportOptions configures a report.
+type ReportOptions struct {
+	// Width is the width of the chart in columns.
+	Width int
+
+	// TimelineLimit is the number of timeline entries shown, zero shows all of them.
+	TimelineLimit int
+}
+
+// WriteReport writes a summary of r, its timeline and a chart to w.
+func WriteReport(w io.Writer, r *Result, opts ReportOptions) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "🐾 %s over %s, decaying every %s\n", r.Nickname, r.Duration, r.DecayInterval)
+	if r.Hungry {
+		fmt.Fprintf(&b, "😭 Hungry after %s\n", r.HungryAfter)
+	} else {
+		fmt.Fprintln(&b, "😋 Never hungry")
+	}
+	final := r.Final()
+	if r.Dead {
+		fmt.Fprintf(&b, "☠️ Died after %s\n", r.DeadAfter)
+	} else {
+		fmt.Fprintf(&b, "💪 Survived with food %d, love %d and health %d as %s\n",
+			final.Food, final.Love, final.Health, strings.ToLower(string(final.Stage)))
+	}
+
+	fmt.Fprintln(&b, "\nTimeline:")
+	tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	timeline := r.Timeline
+	if opts.TimelineLimit > 0 && len(timeline) > opts.TimelineLimit {
+		timeline = timeline[:opts.TimelineLimit]
+	}
+	for _, entry := range timeline {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", entry.At, entry.Type, entry.Reason, entry.Message)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if hidden := len(r.Timeline) - len(timeline); hidden > 0 {
+		fmt.Fprintf(&b, "  … %d more events\n", hidden)
+	}
+
+	fmt.Fprintln(&b, "\nChart:")
+	b.WriteString(Chart(r, opts.Width))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Chart draws the food, love and health of the pet over the simulation as
+// width bars each, one bar per slice of the simulated time.
+func Chart(r *Result, width int) string {
+	width = max(width, 2)
+	columns := make([]engine.State, width)
+	for i := range columns {
+		columns[i] = r.at(time.Duration(int64(r.Duration) * int64(i) / int64(width-1)))
+	}
+
+	var b strings.Builder
+	for _, row := range []struct {
+		name  string
+		value func(engine.State) int
+	}{
+		{"food", func(s engine.State) int { return s.Food }},
+		{"love", func(s engine.State) int { return s.Love }},
+		{"health", func(s engine.State) int { return s.Health }},
+	} {
+		fmt.Fprintf(&b, "  %-6s │", row.name)
+		for _, s := range columns {
+			b.WriteRune(levels[min(max(row.value(s), 0), 100)*(len(levels)-1)/100])
+		}
+		b.WriteString("│\n")
+	}
+
+	end := r.Duration.String()
+	fmt.Fprintf(&b, "  %-6s  0s%s%s\n", "", strings.Repeat(" ", max(width-2-len(end), 1)), end)
+	return b.String()
+}
+
+// at returns the state of the pet at the given time since the start.
+func (r *Result) at(at time.Duration) engine.State {
+	i := sort.Search(len(r.Samples), func(i int) bool { return r.Samples[i].At > at })
+	return r.Samples[max(i-1, 0)].State
+}
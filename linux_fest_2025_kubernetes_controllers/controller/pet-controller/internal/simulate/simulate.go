@@ -0,0 +1,207 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulate plays the game rules for a single pet against a virtual
+// clock, so its survival can be predicted before it is deployed.
+package simulate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/pkg/engine"
+	"github.com/itzloop/pet-controller/pkg/engine/kube"
+)
+
+// start is when every simulation starts, the pet is born at this time.
+var start = time.Date(2025, 4, 26, 0, 0, 0, 0, time.UTC)
+
+// Options configures a simulation.
+type Options struct {
+	// Duration is how long the pet is simulated for.
+	Duration time.Duration
+
+	// FeedEvery is the interval the pet is fed at, zero never feeds it.
+	FeedEvery time.Duration
+
+	// FeedAmount is the food given on every feeding.
+	FeedAmount int
+
+	// PetEvery is the interval the pet is petted at, zero never pets it.
+	PetEvery time.Duration
+
+	// PetAmount is the love given on every petting.
+	PetAmount int
+}
+
+// Sample is the state of the pet at a point of the simulation.
+type Sample struct {
+	// At is the time since the simulation started.
+	At time.Duration
+
+	engine.State
+}
+
+// TimelineEntry is an event on the timeline of a simulation.
+type TimelineEntry struct {
+	// At is the time since the simulation started.
+	At time.Duration
+
+	engine.Event
+}
+
+// Result is the outcome of a simulation.
+type Result struct {
+	Nickname      string
+	Duration      time.Duration
+	DecayInterval time.Duration
+
+	// Samples are the states of the pet after every change, in order.
+	Samples []Sample
+
+	// Timeline are the events of the pet, in order.
+	Timeline []TimelineEntry
+
+	// Hungry reports whether the pet became hungry, HungryAfter is when it first did.
+	Hungry      bool
+	HungryAfter time.Duration
+
+	// Dead reports whether the pet died, DeadAfter is when it did.
+	Dead      bool
+	DeadAfter time.Duration
+}
+
+// Final returns the state the pet ended the simulation in.
+func (r *Result) Final() engine.State {
+	return r.Samples[len(r.Samples)-1].State
+}
+
+// record adds s and the events that led to it at at.
+func (r *Result) record(at time.Duration, s engine.State, events []engine.Event) {
+	r.Samples = append(r.Samples, Sample{At: at, State: s})
+	for _, e := range events {
+		r.Timeline = append(r.Timeline, TimelineEntry{At: at, Event: e})
+	}
+	if s.Is(engine.Hungry) && !r.Hungry {
+		r.Hungry, r.HungryAfter = true, at
+	}
+	if s.Is(engine.Dead) && !r.Dead {
+		r.Dead, r.DeadAfter = true, at
+	}
+}
+
+// Load reads a Pet manifest from r and fills in the defaults the Pet CRD would.
+func Load(r io.Reader) (*linuxfestv2025.Pet, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var pet linuxfestv2025.Pet
+	if err := yaml.Unmarshal(data, &pet); err != nil {
+		return nil, fmt.Errorf("decoding pet: %w", err)
+	}
+	if pet.Kind != "" && pet.Kind != "Pet" {
+		return nil, fmt.Errorf("expected a Pet, got a %s", pet.Kind)
+	}
+
+	if pet.Spec.FoodDecayRate == 0 {
+		pet.Spec.FoodDecayRate = 1
+	}
+	if pet.Spec.LoveDecayRate == 0 {
+		pet.Spec.LoveDecayRate = 1
+	}
+	if pet.Spec.DecayInterval.Duration == 0 {
+		pet.Spec.DecayInterval.Duration = 10 * time.Second
+	}
+	return &pet, nil
+}
+
+// Run simulates pet playing by rules for opts.Duration. Pets without a status
+// are born when the simulation starts. Decay happens before actions that are
+// due at the same time, and the simulation ends early when the pet dies.
+func Run(pet *linuxfestv2025.Pet, rules engine.Rules, opts Options) (*Result, error) {
+	interval := pet.Spec.DecayInterval.Duration
+	if interval <= 0 {
+		return nil, errors.New("the decay interval must be positive")
+	}
+	if opts.Duration <= 0 {
+		return nil, errors.New("the duration must be positive")
+	}
+	if opts.FeedEvery < 0 || opts.PetEvery < 0 {
+		return nil, errors.New("feeding and petting intervals must not be negative")
+	}
+
+	result := &Result{Nickname: pet.Spec.Nickname, Duration: opts.Duration, DecayInterval: interval}
+
+	// 🐣 Pets are born when the simulation starts, unless they already were
+	p := kube.Pet(pet)
+	p.Born = start
+	var events []engine.Event
+	if !pet.Status.Initialized && pet.Status.Food == 0 && pet.Status.Love == 0 {
+		p.State, events = engine.Init(p, rules, start)
+	} else {
+		p.State, events = engine.Evaluate(p, rules, start)
+	}
+	result.record(0, p.State, events)
+
+	nextDecay, nextFeed, nextPet := interval, opts.FeedEvery, opts.PetEvery
+	for !p.Is(engine.Dead) {
+		at := nextDecay
+		if opts.FeedEvery > 0 {
+			at = min(at, nextFeed)
+		}
+		if opts.PetEvery > 0 {
+			at = min(at, nextPet)
+		}
+		if at > opts.Duration {
+			break
+		}
+		now := start.Add(at)
+
+		// 🧓 Decay first, like the controller does once a decay interval passed
+		if at == nextDecay {
+			p.State, events = engine.Decay(p, rules, now, 0)
+			result.record(at, p.State, events)
+			nextDecay += interval
+			if p.Is(engine.Dead) {
+				break
+			}
+		}
+
+		// 🧃 Then feed and pet the pet when it is time
+		food, love := 0, 0
+		if opts.FeedEvery > 0 && at == nextFeed {
+			food = opts.FeedAmount
+			nextFeed += opts.FeedEvery
+		}
+		if opts.PetEvery > 0 && at == nextPet {
+			love = opts.PetAmount
+			nextPet += opts.PetEvery
+		}
+		if food > 0 || love > 0 {
+			p.State, events = engine.Act(p, rules, now, food, love)
+			result.record(at, p.State, events)
+		}
+	}
+
+	return result, nil
+}
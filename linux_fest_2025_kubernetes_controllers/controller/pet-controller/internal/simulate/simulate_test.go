@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulate
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/config"
+	"github.com/itzloop/pet-controller/pkg/engine"
+)
+
+const barky = `apiVersion: linuxfest.example.com/v2025
+kind: Pet
+metadata:
+  name: barky
+spec:
+  nickname: Barky
+  foodDecayRate: 10
+  loveDecayRate: 10
+  decayInterval: "1s"
+`
+
+// tunedRules returns the default rules with babies that decay faster and hold
+// at most 80 food.
+func tunedRules() engine.Rules {
+	cfg := config.Default()
+	cfg.Stages.Baby = config.StageConfig{DecayMultiplier: 1.5, MaxFood: 80, MaxLove: 100}
+	return cfg.Rules("")
+}
+
+var _ = Describe("Load", func() {
+	It("should fill in the defaults of the Pet CRD", func() {
+		pet, err := Load(strings.NewReader("kind: Pet\nspec:\n  nickname: Rex\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pet.Spec.FoodDecayRate).To(Equal(1))
+		Expect(pet.Spec.LoveDecayRate).To(Equal(1))
+		Expect(pet.Spec.DecayInterval.Duration).To(Equal(10 * time.Second))
+	})
+
+	It("should reject other kinds", func() {
+		_, err := Load(strings.NewReader("kind: Household\n"))
+		Expect(err).To(MatchError(ContainSubstring("expected a Pet")))
+	})
+})
+
+var _ = Describe("Run", func() {
+	var (
+		pet   *linuxfestv2025.Pet
+		rules engine.Rules
+	)
+
+	BeforeEach(func() {
+		var err error
+		pet, err = Load(strings.NewReader(barky))
+		Expect(err).NotTo(HaveOccurred())
+		rules = tunedRules()
+	})
+
+	It("should predict when a neglected pet gets hungry and dies", func() {
+		result, err := Run(pet, rules, Options{Duration: 24 * time.Hour})
+		Expect(err).NotTo(HaveOccurred())
+
+		// 🧮 Babies lose 15 food a second, health goes after three neglected seconds
+		Expect(result.Hungry).To(BeTrue())
+		Expect(result.HungryAfter).To(Equal(4 * time.Second))
+		Expect(result.Dead).To(BeTrue())
+		Expect(result.DeadAfter).To(Equal(15 * time.Second))
+		Expect(result.Final().Health).To(BeZero())
+
+		var timeline []string
+		for _, entry := range result.Timeline {
+			timeline = append(timeline, entry.Reason)
+		}
+		Expect(timeline).To(Equal([]string{"Initialized", "NeedFood", "NeedLove", "Sick", "Dead"}))
+	})
+
+	It("should keep a pet that is cared for alive", func() {
+		result, err := Run(pet, rules, Options{
+			Duration:  5 * time.Minute,
+			FeedEvery: time.Second, FeedAmount: 15,
+			PetEvery: time.Second, PetAmount: 15,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Hungry).To(BeFalse())
+		Expect(result.Dead).To(BeFalse())
+		Expect(result.Samples[len(result.Samples)-1].At).To(Equal(5 * time.Minute))
+		Expect(result.Final()).To(HaveField("Food", 80))
+	})
+
+	It("should continue from the status of pets that were already initialized", func() {
+		pet.Status = linuxfestv2025.PetStatus{Initialized: true, Food: 20, Love: 100, Health: 100,
+			Stage: linuxfestv2025.PetStageAdult}
+		result, err := Run(pet, rules, Options{Duration: time.Second})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Hungry).To(BeTrue())
+		Expect(result.HungryAfter).To(BeZero())
+		Expect(result.Final().Food).To(Equal(10))
+	})
+
+	It("should let a grown up pet fed the same way starve once overfed", func() {
+		result, err := Run(pet, rules, Options{
+			Duration:  time.Hour,
+			FeedEvery: time.Second, FeedAmount: 15,
+			PetEvery: time.Second, PetAmount: 15,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Dead).To(BeTrue())
+		Expect(result.DeadAfter).To(BeNumerically(">", 10*time.Minute))
+		Expect(result.Timeline).To(ContainElement(HaveField("Event.Reason", "Overfed")))
+	})
+
+	It("should reject simulations that never advance", func() {
+		_, err := Run(pet, rules, Options{})
+		Expect(err).To(HaveOccurred())
+
+		pet.Spec.DecayInterval.Duration = 0
+		_, err = Run(pet, rules, Options{Duration: time.Hour})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("WriteReport", func() {
+	It("should summarize the simulation with a timeline and a chart", func() {
+		pet, err := Load(strings.NewReader(barky))
+		Expect(err).NotTo(HaveOccurred())
+		result, err := Run(pet, tunedRules(), Options{Duration: time.Minute})
+		Expect(err).NotTo(HaveOccurred())
+
+		var b strings.Builder
+		Expect(WriteReport(&b, result, ReportOptions{Width: 10, TimelineLimit: 2})).To(Succeed())
+		Expect(b.String()).To(ContainSubstring("😭 Hungry after 4s"))
+		Expect(b.String()).To(ContainSubstring("☠️ Died after 15s"))
+		Expect(b.String()).To(ContainSubstring("🐣 Barky was born"))
+		Expect(b.String()).To(ContainSubstring("… 3 more events"))
+		Expect(b.String()).To(ContainSubstring("food   │▆         │"))
+	})
+})
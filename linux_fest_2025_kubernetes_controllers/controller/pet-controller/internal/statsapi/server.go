@@ -0,0 +1,351 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statsapi serves read-only pet stats over HTTP for dashboards that
+// have no kube credentials.
+package statsapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+)
+
+// PetView is the JSON representation of a pet.
+type PetView struct {
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Nickname  string    `json:"nickname"`
+	Stage     string    `json:"stage,omitempty"`
+	Food      int       `json:"food"`
+	Love      int       `json:"love"`
+	Health    int       `json:"health"`
+	CareScore int       `json:"careScore"`
+	Revivals  int       `json:"revivals"`
+	Hungry    bool      `json:"hungry"`
+	Lonely    bool      `json:"lonely"`
+	Sick      bool      `json:"sick"`
+	Dead      bool      `json:"dead"`
+	Born      time.Time `json:"born"`
+}
+
+// LeaderboardEntry is a living pet ranked by how well it is cared for.
+type LeaderboardEntry struct {
+	Rank int `json:"rank"`
+	PetView
+}
+
+// Change is a pet change sent on the event stream.
+type Change struct {
+	// Type is added, updated or deleted.
+	Type string  `json:"type"`
+	Pet  PetView `json:"pet"`
+}
+
+// NewPetView returns the JSON representation of pet.
+func NewPetView(pet *linuxfestv2025.Pet) PetView {
+	conditions := pet.Status.Conditions
+	return PetView{
+		Namespace: pet.Namespace,
+		Name:      pet.Name,
+		Nickname:  pet.Spec.Nickname,
+		Stage:     string(pet.Status.Stage),
+		Food:      pet.Status.Food,
+		Love:      pet.Status.Love,
+		Health:    pet.Status.Health,
+		CareScore: pet.Status.CareScore(),
+		Revivals:  pet.Status.Revivals,
+		Hungry:    meta.IsStatusConditionTrue(conditions, linuxfestv2025.PetConditionHungry),
+		Lonely:    meta.IsStatusConditionTrue(conditions, linuxfestv2025.PetConditionLonely),
+		Sick:      meta.IsStatusConditionTrue(conditions, linuxfestv2025.PetConditionSick),
+		Dead:      meta.IsStatusConditionTrue(conditions, linuxfestv2025.PetConditionDead),
+		Born:      pet.CreationTimestamp.Time,
+	}
+}
+
+// Server serves pet stats from the manager's informer cache. Every request
+// must carry the token as a bearer token. Only /events, which browsers can
+// only open with an EventSource that can not set headers, also takes it in the
+// access_token query parameter.
+type Server struct {
+	// Addr is the address the server listens on.
+	Addr string
+
+	// Token authenticates clients.
+	Token string
+
+	// Cache is the informer cache pets are read from.
+	Cache cache.Cache
+
+	mu          sync.Mutex
+	subscribers map[chan Change]struct{}
+}
+
+// NeedLeaderElection makes every replica serve stats, not just the leader.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves stats on Addr until ctx is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", s.Addr, err)
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve serves stats on ln until ctx is cancelled.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	if s.Token == "" {
+		return errors.New("stats API requires a token")
+	}
+
+	informer, err := s.Cache.GetInformer(ctx, &linuxfestv2025.Pet{})
+	if err != nil {
+		return err
+	}
+	registration, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj any) { s.broadcast("added", obj) },
+		UpdateFunc: func(_, obj any) { s.broadcast("updated", obj) },
+		DeleteFunc: func(obj any) { s.broadcast("deleted", obj) },
+	})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = informer.RemoveEventHandler(registration) }()
+
+	srv := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	log.FromContext(ctx).Info("Serving pet stats", "addr", ln.Addr().String())
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// Handler returns the authenticated stats API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /pets", s.listPets)
+	mux.HandleFunc("GET /pets/{namespace}/{name}", s.getPet)
+	mux.HandleFunc("GET /leaderboard", s.leaderboard)
+	mux.HandleFunc("GET /events", s.events)
+	return s.authenticate(mux)
+}
+
+// authenticate rejects requests without the server's token. Query parameters
+// end up in access logs and proxies, so the token is only read from the query
+// where there is no other way to send it.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" && r.URL.Path == "/events" {
+			token = r.URL.Query().Get("access_token")
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// listPets serves every pet, optionally limited to the namespace query parameter.
+func (s *Server) listPets(w http.ResponseWriter, r *http.Request) {
+	pets, err := s.pets(r.Context(), r.URL.Query().Get("namespace"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, pets)
+}
+
+// getPet serves a single pet.
+func (s *Server) getPet(w http.ResponseWriter, r *http.Request) {
+	var pet linuxfestv2025.Pet
+	key := client.ObjectKey{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+	if err := s.Cache.Get(r.Context(), key, &pet); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			http.Error(w, "pet not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, NewPetView(&pet))
+}
+
+// leaderboard serves the living pets ranked by care score, the longest living first on a tie.
+func (s *Server) leaderboard(w http.ResponseWriter, r *http.Request) {
+	pets, err := s.pets(r.Context(), r.URL.Query().Get("namespace"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	entries := []LeaderboardEntry{}
+	for _, pet := range pets {
+		if !pet.Dead {
+			entries = append(entries, LeaderboardEntry{PetView: pet})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].CareScore != entries[j].CareScore {
+			return entries[i].CareScore > entries[j].CareScore
+		}
+		return entries[i].Born.Before(entries[j].Born)
+	})
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+	writeJSON(w, entries)
+}
+
+// events streams pet changes as Server-Sent Events until the client goes away.
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	changes := s.subscribe()
+	defer s.unsubscribe(changes)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// 💓 Keep idle connections from being closed by proxies
+	heartbeat := time.NewTicker(30 * time.Second)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		case change := <-changes:
+			data, err := json.Marshal(change)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", change.Type, data)
+		}
+		flusher.Flush()
+	}
+}
+
+// pets returns every pet in namespace, or in all namespaces when it is empty.
+func (s *Server) pets(ctx context.Context, namespace string) ([]PetView, error) {
+	var list linuxfestv2025.PetList
+	if err := s.Cache.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	pets := make([]PetView, 0, len(list.Items))
+	for i := range list.Items {
+		pets = append(pets, NewPetView(&list.Items[i]))
+	}
+	sort.Slice(pets, func(i, j int) bool {
+		if pets[i].Namespace != pets[j].Namespace {
+			return pets[i].Namespace < pets[j].Namespace
+		}
+		return pets[i].Name < pets[j].Name
+	})
+	return pets, nil
+}
+
+func (s *Server) subscribe() chan Change {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.subscribers == nil {
+		s.subscribers = map[chan Change]struct{}{}
+	}
+	changes := make(chan Change, 16)
+	s.subscribers[changes] = struct{}{}
+	return changes
+}
+
+func (s *Server) unsubscribe(changes chan Change) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.subscribers, changes)
+}
+
+// broadcast sends a change of obj to every subscriber. Subscribers that fall
+// behind miss changes rather than slowing down the informer.
+func (s *Server) broadcast(changeType string, obj any) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pet, ok := obj.(*linuxfestv2025.Pet)
+	if !ok {
+		return
+	}
+	change := Change{Type: changeType, Pet: NewPetView(pet)}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for changes := range s.subscribers {
+		select {
+		case changes <- change:
+		default:
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statsapi
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+)
+
+// get requests path from the server under test with the token.
+func get(path string) *http.Response {
+	GinkgoHelper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path, nil)
+	Expect(err).NotTo(HaveOccurred())
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(resp.Body.Close)
+	return resp
+}
+
+// createPet creates a pet in namespace default with the given status.
+func createPet(name string, status linuxfestv2025.PetStatus) *linuxfestv2025.Pet {
+	GinkgoHelper()
+
+	pet := &linuxfestv2025.Pet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec:       linuxfestv2025.PetSpec{Nickname: strings.ToUpper(name)},
+	}
+	Expect(k8sClient.Create(ctx, pet)).To(Succeed())
+	DeferCleanup(k8sClient.Delete, ctx, pet)
+
+	pet.Status = status
+	Expect(k8sClient.Status().Update(ctx, pet)).To(Succeed())
+	return pet
+}
+
+var _ = Describe("Stats API", func() {
+	It("should reject requests without the token", func() {
+		resp, err := http.Get(baseURL + "/pets")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should only take the token from the query to stream events", func() {
+		resp, err := http.Get(baseURL + "/pets?access_token=" + token)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should serve pets from the cache", func() {
+		createPet("rex", linuxfestv2025.PetStatus{Food: 70, Love: 40, Health: 90})
+
+		Eventually(func(g Gomega) {
+			resp := get("/pets/default/rex")
+			g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			var pet PetView
+			g.Expect(json.NewDecoder(resp.Body).Decode(&pet)).To(Succeed())
+			g.Expect(pet.Nickname).To(Equal("REX"))
+			g.Expect(pet.Food).To(Equal(70))
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			var pets []PetView
+			g.Expect(json.NewDecoder(get("/pets?namespace=default").Body).Decode(&pets)).To(Succeed())
+			g.Expect(pets).To(ContainElement(HaveField("Name", "rex")))
+		}).Should(Succeed())
+
+		Expect(get("/pets/default/missing").StatusCode).To(Equal(http.StatusNotFound))
+	})
+
+	It("should rank living pets by care score", func() {
+		createPet("fluffy", linuxfestv2025.PetStatus{Food: 50, Love: 50, Health: 100, Ticks: 10, CaredTicks: 5})
+		createPet("spot", linuxfestv2025.PetStatus{Food: 50, Love: 50, Health: 100, Ticks: 10, CaredTicks: 9})
+		createPet("ghost", linuxfestv2025.PetStatus{Conditions: []metav1.Condition{{
+			Type: linuxfestv2025.PetConditionDead, Status: metav1.ConditionTrue,
+			Reason: linuxfestv2025.PetConditionDead, LastTransitionTime: metav1.Now(),
+		}}})
+
+		Eventually(func(g Gomega) {
+			var entries []LeaderboardEntry
+			g.Expect(json.NewDecoder(get("/leaderboard?namespace=default").Body).Decode(&entries)).To(Succeed())
+
+			names := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				names = append(names, entry.Name)
+			}
+			g.Expect(names).To(Equal([]string{"spot", "fluffy"}))
+			g.Expect(entries[0].Rank).To(Equal(1))
+		}).Should(Succeed())
+	})
+
+	It("should stream pet changes", func() {
+		resp := get("/events?access_token=" + token)
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+
+		createPet("buddy", linuxfestv2025.PetStatus{Food: 10})
+
+		changes := make(chan Change)
+		go func() {
+			defer GinkgoRecover()
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+				if !ok {
+					continue
+				}
+				var change Change
+				Expect(json.Unmarshal([]byte(data), &change)).To(Succeed())
+				select {
+				case changes <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		Eventually(changes).Should(Receive(And(
+			HaveField("Type", "updated"),
+			HaveField("Pet.Name", "buddy"),
+			HaveField("Pet.Food", 10),
+		)))
+	})
+})
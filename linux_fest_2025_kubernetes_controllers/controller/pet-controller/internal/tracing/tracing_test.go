@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+)
+
+// fakeCollector is a stand-in for an OTLP collector that remembers the names
+// of the spans it received.
+type fakeCollector struct {
+	collectortrace.UnimplementedTraceServiceServer
+
+	mu    sync.Mutex
+	spans []string
+}
+
+func (c *fakeCollector) Export(_ context.Context, req *collectortrace.ExportTraceServiceRequest) (*collectortrace.ExportTraceServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rs := range req.GetResourceSpans() {
+		for _, ss := range rs.GetScopeSpans() {
+			for _, s := range ss.GetSpans() {
+				c.spans = append(c.spans, s.GetName())
+			}
+		}
+	}
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+func (c *fakeCollector) SpanNames() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.spans...)
+}
+
+var _ = Describe("Setup", func() {
+	It("should be a no-op without an endpoint", func() {
+		shutdown, err := Setup(context.Background(), Options{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(shutdown(context.Background())).To(Succeed())
+	})
+
+	It("should reject an out of range sample ratio", func() {
+		_, err := Setup(context.Background(), Options{Endpoint: "localhost:4317", SampleRatio: 2})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should export spans to the collector", func() {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		collector := &fakeCollector{}
+		srv := grpc.NewServer()
+		collectortrace.RegisterTraceServiceServer(srv, collector)
+		go func() { _ = srv.Serve(lis) }()
+		DeferCleanup(srv.Stop)
+
+		ctx := context.Background()
+		shutdown, err := Setup(ctx, Options{
+			Endpoint:    lis.Addr().String(),
+			Insecure:    true,
+			SampleRatio: 1,
+			ServiceName: "pet-controller-test",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, span := otel.Tracer("test").Start(ctx, "Reconcile")
+		span.End()
+
+		By("flushing spans on shutdown")
+		Expect(shutdown(ctx)).To(Succeed())
+		Expect(collector.SpanNames()).To(ContainElement("Reconcile"))
+	})
+})
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2025
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/internal/config"
+)
+
+// nolint:unused
+// log is for logging in this package.
+var petlog = logf.Log.WithName("pet-resource")
+
+// SetupPetWebhookWithManager registers the webhook for Pet in the manager.
+func SetupPetWebhookWithManager(mgr ctrl.Manager, store *config.Store) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&linuxfestv2025.Pet{}).
+		WithDefaulter(&PetCustomDefaulter{Config: store}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-linuxfest-example-com-v2025-pet,mutating=true,failurePolicy=fail,sideEffects=None,groups=linuxfest.example.com,resources=pets,verbs=create;update,versions=v2025,name=mpet-v2025.kb.io,admissionReviewVersions=v1
+
+// PetCustomDefaulter owns the requester annotation so the controller knows who
+// to credit. It stamps the requester on pets whose feed, pet or revive
+// annotation is set or changed, keeps the requester of pending actions and
+// removes it otherwise, whatever the client sent. The webhook fails closed so
+// no pet is written with a requester it did not check.
+type PetCustomDefaulter struct {
+	// Config holds the action annotation keys, the defaults are used when nil.
+	Config *config.Store
+}
+
+var _ webhook.CustomDefaulter = &PetCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind Pet.
+func (d *PetCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	pet, ok := obj.(*linuxfestv2025.Pet)
+	if !ok {
+		return fmt.Errorf("expected an Pet object but got %T", obj)
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	var old linuxfestv2025.Pet
+	if len(req.OldObject.Raw) > 0 {
+		if err := json.Unmarshal(req.OldObject.Raw, &old); err != nil {
+			return fmt.Errorf("decoding old pet: %w", err)
+		}
+	}
+
+	requester, stamped := old.Annotations[linuxfestv2025.RequesterAnnotation]
+	switch actions := d.actions(); {
+	case actionRequested(actions, &old, pet):
+		petlog.V(1).Info("Stamping requester", "name", pet.GetName(), "requester", req.UserInfo.Username)
+		requester, stamped = req.UserInfo.Username, true
+	case !actionPending(actions, pet):
+		stamped = false
+	}
+
+	if !stamped {
+		delete(pet.Annotations, linuxfestv2025.RequesterAnnotation)
+		return nil
+	}
+	if pet.Annotations == nil {
+		pet.Annotations = map[string]string{}
+	}
+	pet.Annotations[linuxfestv2025.RequesterAnnotation] = requester
+	return nil
+}
+
+// actions returns the keys of the feed, pet and revive annotations.
+func (d *PetCustomDefaulter) actions() []string {
+	cfg := config.Default()
+	if d.Config != nil {
+		cfg = d.Config.Get()
+	}
+	return []string{cfg.Annotations.Feed, cfg.Annotations.Pet, cfg.Annotations.Revive}
+}
+
+// actionRequested reports whether an action annotation was added or changed from old to pet.
+func actionRequested(actions []string, old, pet *linuxfestv2025.Pet) bool {
+	for _, key := range actions {
+		value, ok := pet.Annotations[key]
+		if ok && value != old.Annotations[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// actionPending reports whether pet has an action annotation.
+func actionPending(actions []string, pet *linuxfestv2025.Pet) bool {
+	for _, key := range actions {
+		if _, ok := pet.Annotations[key]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2025
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+)
+
+var _ = Describe("Pet Webhook", func() {
+	var (
+		defaulter *PetCustomDefaulter
+		oldPet    *linuxfestv2025.Pet
+		pet       *linuxfestv2025.Pet
+	)
+
+	// admit runs the defaulter on pet as an update of oldPet by alice.
+	admit := func() error {
+		raw, err := json.Marshal(oldPet)
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				UserInfo:  authenticationv1.UserInfo{Username: "alice"},
+				OldObject: runtime.RawExtension{Raw: raw},
+			},
+		})
+		return defaulter.Default(ctx, pet)
+	}
+
+	BeforeEach(func() {
+		defaulter = &PetCustomDefaulter{}
+		oldPet = &linuxfestv2025.Pet{ObjectMeta: metav1.ObjectMeta{Name: "rex", Namespace: "default"}}
+		pet = oldPet.DeepCopy()
+	})
+
+	It("should stamp the requester of a new action", func() {
+		pet.Annotations = map[string]string{"linuxfest.example.com/feed": "10"}
+
+		Expect(admit()).To(Succeed())
+		Expect(pet.Annotations).To(HaveKeyWithValue(linuxfestv2025.RequesterAnnotation, "alice"))
+	})
+
+	It("should keep the requester of a pending action", func() {
+		oldPet.Annotations = map[string]string{
+			"linuxfest.example.com/feed":       "10",
+			linuxfestv2025.RequesterAnnotation: "bob",
+		}
+		pet = oldPet.DeepCopy()
+		pet.Labels = map[string]string{"team": "blue"}
+
+		Expect(admit()).To(Succeed())
+		Expect(pet.Annotations).To(HaveKeyWithValue(linuxfestv2025.RequesterAnnotation, "bob"))
+	})
+
+	It("should not stamp pets without actions", func() {
+		Expect(admit()).To(Succeed())
+		Expect(pet.Annotations).NotTo(HaveKey(linuxfestv2025.RequesterAnnotation))
+	})
+
+	It("should not let clients pick the requester of a new action", func() {
+		pet.Annotations = map[string]string{
+			"linuxfest.example.com/pet":        "10",
+			linuxfestv2025.RequesterAnnotation: "mallory",
+		}
+
+		Expect(admit()).To(Succeed())
+		Expect(pet.Annotations).To(HaveKeyWithValue(linuxfestv2025.RequesterAnnotation, "alice"))
+	})
+
+	It("should not let clients change the requester of a pending action", func() {
+		oldPet.Annotations = map[string]string{
+			"linuxfest.example.com/feed":       "10",
+			linuxfestv2025.RequesterAnnotation: "bob",
+		}
+		pet = oldPet.DeepCopy()
+		pet.Annotations[linuxfestv2025.RequesterAnnotation] = "mallory"
+
+		Expect(admit()).To(Succeed())
+		Expect(pet.Annotations).To(HaveKeyWithValue(linuxfestv2025.RequesterAnnotation, "bob"))
+	})
+
+	It("should remove requesters set without an action", func() {
+		pet.Annotations = map[string]string{linuxfestv2025.RequesterAnnotation: "mallory"}
+
+		Expect(admit()).To(Succeed())
+		Expect(pet.Annotations).NotTo(HaveKey(linuxfestv2025.RequesterAnnotation))
+	})
+})
@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import "time"
+
+// Condition is a condition a pet can be in.
+type Condition uint8
+
+// Conditions a pet can be in.
+const (
+	Dead Condition = iota
+	Sick
+	Overfed
+	Hungry
+	Lonely
+)
+
+// AllConditions are all conditions in the order their changes are reported.
+var AllConditions = []Condition{Dead, Sick, Overfed, Hungry, Lonely}
+
+var conditionNames = [...]string{"Dead", "Sick", "Overfed", "Hungry", "Lonely"}
+
+// String returns the name of c, which is also its Kubernetes condition type.
+func (c Condition) String() string {
+	return conditionNames[c]
+}
+
+// ConditionSet is a set of conditions.
+type ConditionSet uint8
+
+// Has reports whether c is in s.
+func (s ConditionSet) Has(c Condition) bool {
+	return s&(1<<c) != 0
+}
+
+// With returns s with c added when on is true and removed otherwise.
+func (s ConditionSet) With(c Condition, on bool) ConditionSet {
+	if on {
+		return s | 1<<c
+	}
+	return s &^ (1 << c)
+}
+
+// conditions returns the conditions s is in at now.
+func conditions(s State, rules Rules, now time.Time) ConditionSet {
+	var set ConditionSet
+	set = set.With(Dead, s.Health == 0)
+	set = set.With(Sick, s.Health < rules.Health.SickThreshold)
+	set = set.With(Overfed, overfed(s, now))
+	set = set.With(Hungry, s.Food < rules.HungryThreshold)
+	set = set.With(Lonely, s.Love == 0)
+	return set
+}
+
+type transitionEvent struct {
+	eventType EventType
+	reason    string
+	format    string
+}
+
+// transitionEvents are the events reported when a condition becomes true or
+// false, in the order they are reported.
+var transitionEvents = []struct {
+	condition Condition
+	onTrue    *transitionEvent
+	onFalse   *transitionEvent
+}{
+	{
+		condition: Dead,
+		onTrue:    &transitionEvent{Warning, "Dead", "☠️ %s died"},
+	},
+	{
+		condition: Sick,
+		onTrue:    &transitionEvent{Warning, "Sick", "🤒 %s is sick"},
+		onFalse:   &transitionEvent{Normal, "Recovered", "💪 %s recovered"},
+	},
+	{
+		condition: Overfed,
+		onTrue:    &transitionEvent{Normal, "Overfed", "🤢 %s ate too much"},
+		onFalse:   &transitionEvent{Normal, "Digested", "😌 %s digested its meal"},
+	},
+	{
+		condition: Hungry,
+		onTrue:    &transitionEvent{Warning, "NeedFood", "😭%s Needs Food"},
+		onFalse:   &transitionEvent{Normal, "NoLongerHungry", "😋 %s is no longer hungry"},
+	},
+	{
+		condition: Lonely,
+		onTrue:    &transitionEvent{Warning, "NeedLove", "😢 %s Needs Love and Attention"},
+		onFalse:   &transitionEvent{Normal, "NoLongerLonely", "🥰 %s feels loved again"},
+	},
+}
+
+// transitions returns the events of the conditions that changed from prev to
+// cur. A pet that just died only reports its death, and dead pets do not recover.
+func transitions(nickname string, prev, cur ConditionSet) []Event {
+	var events []Event
+	for _, te := range transitionEvents {
+		if prev.Has(te.condition) == cur.Has(te.condition) {
+			continue
+		}
+
+		transition := te.onFalse
+		if cur.Has(te.condition) {
+			transition = te.onTrue
+		}
+		if transition == nil || (cur.Has(Dead) && te.condition != Dead) {
+			continue
+		}
+		events = append(events, event(transition.eventType, transition.reason, transition.format, nickname))
+	}
+	return events
+}
@@ -0,0 +1,336 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package engine implements the rules of the pet game without any Kubernetes
+// dependency. A pet's state, the time and the actions taken on it go in, its
+// new state and the events worth telling its owners come out. The controller,
+// the TUI and petctl all play by these rules.
+package engine
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Stage is a life stage of a pet.
+type Stage string
+
+// Life stages a pet goes through, in order.
+const (
+	Baby   Stage = "Baby"
+	Adult  Stage = "Adult"
+	Senior Stage = "Senior"
+)
+
+// Pet is a pet as the rules see it.
+type Pet struct {
+	Nickname string
+
+	// FoodDecayRate and LoveDecayRate are the food and love the pet loses every
+	// decay interval before its stage and overfeeding scale them.
+	FoodDecayRate int
+	LoveDecayRate int
+
+	// Born is when the pet was created.
+	Born time.Time
+
+	State
+}
+
+// State is the part of a pet the rules change.
+type State struct {
+	Food   int
+	Love   int
+	Health int
+
+	// NeglectedTicks is the number of consecutive decay intervals the pet was neglected.
+	NeglectedTicks int
+
+	Stage Stage
+
+	// Ticks is the number of decay intervals the pet lived through.
+	Ticks int
+
+	// CaredTicks is the number of decay intervals the pet ended neither hungry nor lonely.
+	CaredTicks int
+
+	// Overfeedings is the number of times the pet was overfed.
+	Overfeedings int
+
+	// OverfedUntil is when the pet stops being overfed.
+	OverfedUntil time.Time
+
+	// Conditions are the conditions the pet was in when it was last evaluated.
+	Conditions ConditionSet
+}
+
+// Is reports whether the pet was in condition when it was last evaluated.
+func (s State) Is(condition Condition) bool {
+	return s.Conditions.Has(condition)
+}
+
+// CareScore is the percentage of decay intervals the pet ended neither hungry nor lonely.
+func (s State) CareScore() int {
+	if s.Ticks == 0 {
+		return 100
+	}
+	return s.CaredTicks * 100 / s.Ticks
+}
+
+// EventType tells apart events that need attention from the others. The values
+// match the Kubernetes event types.
+type EventType string
+
+// Event types.
+const (
+	Normal  EventType = "Normal"
+	Warning EventType = "Warning"
+)
+
+// Event is something that happened to a pet.
+type Event struct {
+	Type    EventType
+	Reason  string
+	Message string
+}
+
+func event(eventType EventType, reason, format string, args ...any) Event {
+	return Event{Type: eventType, Reason: reason, Message: fmt.Sprintf(format, args...)}
+}
+
+// Init returns the state a newly created pet starts with.
+func Init(p Pet, rules Rules, now time.Time) (State, []Event) {
+	s := State{Stage: Baby, Health: rules.Health.Initial}
+	maxFood, maxLove := rules.Capacity(Baby)
+	s.Food = min(rules.InitialFood, maxFood)
+	s.Love = min(rules.InitialLove, maxLove)
+	s.Conditions = conditions(s, rules, now)
+
+	return s, []Event{event(Normal, "Initialized", "🐣 %s was born", p.Nickname)}
+}
+
+// Decay ages p by one decay interval ending at now. bonus is the love its
+// friends gave it during the interval.
+func Decay(p Pet, rules Rules, now time.Time, bonus int) (State, []Event) {
+	s := p.State
+	foodRate, loveRate := DecayRates(p, rules, now)
+	s.Food = max(s.Food-foodRate, 0)
+	s.Love = max(s.Love-loveRate, 0) + bonus
+
+	updateHealth(&s, rules)
+	age(&s, p.Born, rules, now)
+	s.Conditions = conditions(s, rules, now)
+
+	var events []Event
+	if p.Stage != "" && p.Stage != s.Stage {
+		if s.Stage == Senior {
+			events = append(events, event(Normal, "GrewUp", "🧓 %s became a senior", p.Nickname))
+		} else {
+			events = append(events, event(Normal, "GrewUp", "🎂 %s grew into an adult", p.Nickname))
+		}
+	}
+	return s, append(events, transitions(p.Nickname, p.Conditions, s.Conditions)...)
+}
+
+// DecayRates returns p's food and love decay rates at now scaled by its stage.
+// Overfed pets digest their food faster.
+func DecayRates(p Pet, rules Rules, now time.Time) (food, love int) {
+	multiplier := rules.Stages.stage(p.Stage).DecayMultiplier
+	foodMultiplier := multiplier
+	if overfed(p.State, now) {
+		foodMultiplier *= rules.Overfeeding.DecayMultiplier
+	}
+	return int(math.Ceil(float64(p.FoodDecayRate) * foodMultiplier)),
+		int(math.Ceil(float64(p.LoveDecayRate) * multiplier))
+}
+
+// Act feeds p food and pets it with love at now. Amounts that are not positive
+// are ignored and so are dead pets. Food and love are capped by the pet's stage,
+// feeding beyond the overfeeding cap overfeeds it.
+func Act(p Pet, rules Rules, now time.Time, food, love int) (State, []Event) {
+	s := p.State
+	if p.Is(Dead) {
+		return s, nil
+	}
+
+	var events []Event
+	maxFood, maxLove := rules.Capacity(s.Stage)
+	if food > 0 {
+		penalty := overfeed(&s, food, maxFood, rules.Overfeeding, now)
+		s.Food = min(s.Food+food, maxFood)
+		events = append(events, event(Normal, "Fed", "🍗 %s was fed, food %d → %d", p.Nickname, p.Food, s.Food))
+		if penalty > 0 {
+			events = append(events, event(Warning, "Overeating",
+				"🤮 %s was overfed again and lost %d health", p.Nickname, penalty))
+		}
+	}
+	if love > 0 {
+		s.Love = min(s.Love+loveGain(s, love, rules.Overfeeding, now), maxLove)
+		events = append(events, event(Normal, "Petted", "❤️ %s was petted, love %d → %d", p.Nickname, p.Love, s.Love))
+	}
+
+	s.Conditions = conditions(s, rules, now)
+	return s, append(events, transitions(p.Nickname, p.Conditions, s.Conditions)...)
+}
+
+// Heal gives p health at now. Treatments also end a pet's neglect.
+func Heal(p Pet, rules Rules, now time.Time, health int) (State, []Event) {
+	s := p.State
+	s.Health = min(s.Health+health, MaxHealth)
+	s.NeglectedTicks = 0
+	s.Conditions = conditions(s, rules, now)
+
+	events := []Event{event(Normal, "Treated", "💊 %s was treated, health %d → %d", p.Nickname, p.Health, s.Health)}
+	return s, append(events, transitions(p.Nickname, p.Conditions, s.Conditions)...)
+}
+
+// Grieve makes p lose love at now for each friend in lost, who died. Dead pets do not grieve.
+func Grieve(p Pet, rules Rules, now time.Time, lost []string) (State, []Event) {
+	s := p.State
+	var events []Event
+	if !p.Is(Dead) {
+		s.Love = max(s.Love-len(lost)*rules.Social.GriefPenalty, 0)
+		for _, friend := range lost {
+			events = append(events, event(Warning, "Grieving", "💔 %s lost their friend %s", p.Nickname, friend))
+		}
+	}
+
+	s.Conditions = conditions(s, rules, now)
+	return s, append(events, transitions(p.Nickname, p.Conditions, s.Conditions)...)
+}
+
+// Revive brings p back to life at now with its initial food, love and health.
+func Revive(p Pet, rules Rules, now time.Time) (State, []Event) {
+	s := p.State
+	maxFood, maxLove := rules.Capacity(s.Stage)
+	s.Food = min(rules.InitialFood, maxFood)
+	s.Love = min(rules.InitialLove, maxLove)
+	s.Health = rules.Health.Initial
+	s.NeglectedTicks = 0
+	s.Conditions = conditions(s, rules, now)
+
+	return s, transitions(p.Nickname, p.Conditions, s.Conditions)
+}
+
+// Evaluate recomputes the conditions of p at now.
+func Evaluate(p Pet, rules Rules, now time.Time) (State, []Event) {
+	s := p.State
+	s.Conditions = conditions(s, rules, now)
+	return s, transitions(p.Nickname, p.Conditions, s.Conditions)
+}
+
+// FriendshipBonus is the love p gains during a decay interval from its happy friends.
+func FriendshipBonus(p State, friends []State, rules Rules) int {
+	if p.Love < rules.Social.FriendshipThreshold {
+		return 0
+	}
+
+	bonus := 0
+	for _, friend := range friends {
+		if friend.Is(Dead) || friend.Love < rules.Social.FriendshipThreshold {
+			continue
+		}
+		bonus += rules.Social.FriendshipBonus
+	}
+	return bonus
+}
+
+// updateHealth counts a decay interval in which the pet was neglected or cared
+// for. Pets lose health once they have been neglected for too many intervals in
+// a row and regain it while they are cared for.
+func updateHealth(s *State, rules Rules) {
+	if s.Food < rules.Health.FoodThreshold || s.Love < rules.Health.LoveThreshold {
+		s.NeglectedTicks++
+		if s.NeglectedTicks >= rules.Health.NeglectIntervals {
+			s.Health = max(s.Health-rules.Health.DecayRate, 0)
+		}
+		return
+	}
+
+	s.NeglectedTicks = 0
+	s.Health = min(s.Health+rules.Health.RecoveryRate, MaxHealth)
+}
+
+// age counts a decay interval towards the pet's care score and moves it to the
+// stage it has reached, shrinking its food and love to the new stage's capacity.
+func age(s *State, born time.Time, rules Rules, now time.Time) {
+	s.Ticks++
+	if s.Food >= rules.HungryThreshold && s.Love > 0 {
+		s.CaredTicks++
+	}
+
+	s.Stage = nextStage(*s, born, rules, now)
+	maxFood, maxLove := rules.Capacity(s.Stage)
+	s.Food = min(s.Food, maxFood)
+	s.Love = min(s.Love, maxLove)
+}
+
+// nextStage returns the stage a pet born at born has reached at now. Babies
+// grow up once they are old enough and were cared for well enough, adults
+// become seniors sooner the worse they were cared for. Pets never grow younger.
+func nextStage(s State, born time.Time, rules Rules, now time.Time) Stage {
+	lifetime := now.Sub(born)
+	score := s.CareScore()
+
+	switch s.Stage {
+	case Senior:
+		return Senior
+	case Adult:
+		// 👴 A care score of 100 ages a pet half as fast as a care score of 50
+		seniorAfter := time.Duration(float64(rules.Stages.SeniorAfter) * (0.5 + float64(score)/100))
+		if lifetime >= seniorAfter {
+			return Senior
+		}
+		return Adult
+	default:
+		if lifetime >= rules.Stages.AdultAfter && score >= rules.Stages.AdultMinCare {
+			return Adult
+		}
+		return Baby
+	}
+}
+
+// overfeed marks s overfed at now when feeding it food takes it beyond the
+// overfeeding cap or maxFood. Pets overfed while they are still overfed lose
+// health, overfeed returns how much.
+func overfeed(s *State, food, maxFood int, rules OverfeedingRules, now time.Time) int {
+	if s.Food+food <= min(rules.Cap, maxFood) {
+		return 0
+	}
+
+	penalty := 0
+	if overfed(*s, now) {
+		penalty = min(rules.HealthPenalty, s.Health)
+		s.Health -= penalty
+	}
+	s.Overfeedings++
+	s.OverfedUntil = now.Add(rules.Duration)
+	return penalty
+}
+
+// overfed reports whether s is still overfed at now.
+func overfed(s State, now time.Time) bool {
+	return now.Before(s.OverfedUntil)
+}
+
+// loveGain returns the love s gains from being petted with love at now. Overfed pets gain less.
+func loveGain(s State, love int, rules OverfeedingRules, now time.Time) int {
+	if !overfed(s, now) {
+		return love
+	}
+	return int(math.Floor(float64(love) * rules.LoveGainMultiplier))
+}
@@ -0,0 +1,358 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/itzloop/pet-controller/internal/config"
+	"github.com/itzloop/pet-controller/pkg/engine"
+)
+
+// now is the time every spec is played at.
+var now = time.Date(2025, 4, 26, 12, 0, 0, 0, time.UTC)
+
+// rules are the default rules with tuned stages, babies and seniors decay
+// faster and hold at most 80 food.
+var rules = func() engine.Rules {
+	cfg := config.Default()
+	cfg.Stages.Baby = config.StageConfig{DecayMultiplier: 1.5, MaxFood: 80, MaxLove: 100}
+	cfg.Stages.Senior = config.StageConfig{DecayMultiplier: 1.25, MaxFood: 80, MaxLove: 100}
+	return cfg.Rules("")
+}()
+
+// conditions returns the set of cs.
+func conditions(cs ...engine.Condition) engine.ConditionSet {
+	var set engine.ConditionSet
+	for _, c := range cs {
+		set = set.With(c, true)
+	}
+	return set
+}
+
+// reasons returns the reasons of events.
+func reasons(events []engine.Event) []string {
+	var reasons []string
+	for _, e := range events {
+		reasons = append(reasons, e.Reason)
+	}
+	return reasons
+}
+
+// adult returns an adult, healthy, well fed and loved pet born an hour ago.
+func adult(s engine.State) engine.Pet {
+	if s.Stage == "" {
+		s.Stage = engine.Adult
+	}
+	return engine.Pet{
+		Nickname:      "Rex",
+		FoodDecayRate: 2,
+		LoveDecayRate: 2,
+		Born:          now.Add(-time.Hour),
+		State:         s,
+	}
+}
+
+var _ = Describe("Init", func() {
+	DescribeTable("should start pets as babies",
+		func(r engine.Rules, want engine.State) {
+			s, events := engine.Init(adult(engine.State{Food: 5, Ticks: 3}), r, now)
+			Expect(s).To(Equal(want))
+			Expect(events).To(Equal([]engine.Event{{Type: engine.Normal, Reason: "Initialized", Message: "🐣 Rex was born"}}))
+		},
+		Entry("capped by the baby capacity", rules,
+			engine.State{Food: 80, Love: 100, Health: 100, Stage: engine.Baby}),
+		Entry("in need of care when the initial values are low", func() engine.Rules {
+			r := rules
+			r.InitialFood, r.InitialLove = 10, 0
+			return r
+		}(), engine.State{Food: 10, Health: 100, Stage: engine.Baby, Conditions: conditions(engine.Hungry, engine.Lonely)}),
+	)
+})
+
+var _ = Describe("DecayRates", func() {
+	DescribeTable("should scale the decay rates",
+		func(stage engine.Stage, overfed bool, food, love int) {
+			pet := adult(engine.State{Stage: stage})
+			if overfed {
+				pet.OverfedUntil = now.Add(time.Minute)
+			}
+			gotFood, gotLove := engine.DecayRates(pet, rules, now)
+			Expect(gotFood).To(Equal(food))
+			Expect(gotLove).To(Equal(love))
+		},
+		Entry("of babies", engine.Baby, false, 3, 3),
+		Entry("of adults", engine.Adult, false, 2, 2),
+		Entry("of seniors, rounding up", engine.Senior, false, 3, 3),
+		Entry("of overfed adults", engine.Adult, true, 4, 2),
+		Entry("of overfed babies", engine.Baby, true, 6, 3),
+	)
+})
+
+var _ = Describe("Decay", func() {
+	DescribeTable("should decay food and love",
+		func(before engine.State, bonus int, want engine.State, wantReasons []string) {
+			s, events := engine.Decay(adult(before), rules, now, bonus)
+			Expect(s).To(Equal(want))
+			Expect(reasons(events)).To(Equal(wantReasons))
+		},
+		Entry("by the decay rates",
+			engine.State{Food: 80, Love: 80, Health: 100},
+			0,
+			engine.State{Food: 78, Love: 78, Health: 100, Stage: engine.Adult, Ticks: 1, CaredTicks: 1},
+			nil),
+		Entry("but never below zero",
+			engine.State{Food: 1, Love: 1, Health: 100},
+			0,
+			engine.State{Health: 100, NeglectedTicks: 1, Stage: engine.Adult, Ticks: 1,
+				Conditions: conditions(engine.Hungry, engine.Lonely)},
+			[]string{"NeedFood", "NeedLove"}),
+		Entry("and add the friendship bonus",
+			engine.State{Food: 80, Love: 50, Health: 100},
+			4,
+			engine.State{Food: 78, Love: 52, Health: 100, Stage: engine.Adult, Ticks: 1, CaredTicks: 1},
+			nil),
+		Entry("and report pets that are no longer hungry",
+			engine.State{Food: 80, Love: 80, Health: 100, Conditions: conditions(engine.Hungry)},
+			0,
+			engine.State{Food: 78, Love: 78, Health: 100, Stage: engine.Adult, Ticks: 1, CaredTicks: 1},
+			[]string{"NoLongerHungry"}),
+		Entry("and cost health after sustained neglect",
+			engine.State{Food: 10, Love: 80, Health: 100, NeglectedTicks: 2},
+			0,
+			engine.State{Food: 8, Love: 78, Health: 90, NeglectedTicks: 3, Stage: engine.Adult, Ticks: 1,
+				Conditions: conditions(engine.Hungry)},
+			[]string{"NeedFood"}),
+		Entry("and restore the health of cared for pets",
+			engine.State{Food: 80, Love: 80, Health: 45, NeglectedTicks: 2, Conditions: conditions(engine.Sick)},
+			0,
+			engine.State{Food: 78, Love: 78, Health: 50, Stage: engine.Adult, Ticks: 1, CaredTicks: 1},
+			[]string{"Recovered"}),
+		Entry("and only report the death of a pet",
+			engine.State{Health: 10, NeglectedTicks: 5, Conditions: conditions(engine.Sick, engine.Hungry, engine.Lonely)},
+			0,
+			engine.State{NeglectedTicks: 6, Stage: engine.Adult, Ticks: 1,
+				Conditions: conditions(engine.Dead, engine.Sick, engine.Hungry, engine.Lonely)},
+			[]string{"Dead"}),
+		Entry("and report when overfed pets digested their meal",
+			engine.State{Food: 90, Love: 80, Health: 100, OverfedUntil: now, Conditions: conditions(engine.Overfed)},
+			0,
+			engine.State{Food: 88, Love: 78, Health: 100, Stage: engine.Adult, Ticks: 1, CaredTicks: 1, OverfedUntil: now},
+			[]string{"Digested"}),
+	)
+
+	It("should report a condition once until it clears", func() {
+		pet := adult(engine.State{Food: 31, Love: 80, Health: 100})
+
+		var events []engine.Event
+		pet.State, events = engine.Decay(pet, rules, now, 0)
+		Expect(reasons(events)).To(Equal([]string{"NeedFood"}))
+
+		By("staying hungry")
+		pet.State, events = engine.Decay(pet, rules, now, 0)
+		Expect(pet.Food).To(BeNumerically("<", rules.HungryThreshold))
+		Expect(events).To(BeEmpty())
+
+		By("being fed")
+		pet.State, events = engine.Act(pet, rules, now, 50, 0)
+		Expect(reasons(events)).To(Equal([]string{"Fed", "NoLongerHungry"}))
+
+		By("getting hungry again")
+		pet.Food = 31
+		pet.State, events = engine.Decay(pet, rules, now, 0)
+		Expect(reasons(events)).To(Equal([]string{"NeedFood"}))
+	})
+
+	DescribeTable("should age pets",
+		func(before engine.State, lifetime time.Duration, stage engine.Stage, wantReasons []string) {
+			pet := adult(before)
+			pet.Stage = before.Stage
+			pet.Food, pet.Love, pet.Health = 100, 100, 100
+			pet.Born = now.Add(-lifetime)
+
+			s, events := engine.Decay(pet, rules, now, 0)
+			Expect(s.Stage).To(Equal(stage))
+			Expect(reasons(events)).To(Equal(wantReasons))
+
+			maxFood, maxLove := rules.Capacity(stage)
+			Expect(s.Food).To(BeNumerically("<=", maxFood))
+			Expect(s.Love).To(BeNumerically("<=", maxLove))
+		},
+		Entry("keeping young babies babies",
+			engine.State{Stage: engine.Baby}, 5*time.Minute, engine.Baby, nil),
+		Entry("growing old enough, cared for babies up",
+			engine.State{Stage: engine.Baby, Ticks: 9, CaredTicks: 9}, 10*time.Minute, engine.Adult, []string{"GrewUp"}),
+		Entry("keeping neglected babies babies",
+			engine.State{Stage: engine.Baby, Ticks: 9}, 10*time.Minute, engine.Baby, nil),
+		Entry("aging well cared for adults late",
+			engine.State{Stage: engine.Adult, Ticks: 9, CaredTicks: 9}, 30*time.Hour, engine.Adult, nil),
+		Entry("aging well cared for adults eventually",
+			engine.State{Stage: engine.Adult, Ticks: 9, CaredTicks: 9}, 36*time.Hour, engine.Senior, []string{"GrewUp"}),
+		Entry("aging neglected adults early",
+			engine.State{Stage: engine.Adult, Ticks: 9}, 15*time.Hour, engine.Senior, []string{"GrewUp"}),
+		Entry("never making seniors younger",
+			engine.State{Stage: engine.Senior}, time.Minute, engine.Senior, nil),
+		Entry("not reporting the first stage",
+			engine.State{Stage: ""}, time.Minute, engine.Baby, nil),
+	)
+})
+
+var _ = Describe("Act", func() {
+	DescribeTable("should apply actions",
+		func(before engine.State, food, love int, want engine.State, wantReasons []string) {
+			s, events := engine.Act(adult(before), rules, now, food, love)
+			Expect(s).To(Equal(want))
+			Expect(reasons(events)).To(Equal(wantReasons))
+		},
+		Entry("feeding and petting pets",
+			engine.State{Food: 50, Love: 50, Health: 100},
+			30, 10,
+			engine.State{Food: 80, Love: 60, Health: 100, Stage: engine.Adult},
+			[]string{"Fed", "Petted"}),
+		Entry("reporting pets that are no longer hungry or lonely",
+			engine.State{Food: 10, Health: 100, Conditions: conditions(engine.Hungry, engine.Lonely)},
+			30, 10,
+			engine.State{Food: 40, Love: 10, Health: 100, Stage: engine.Adult},
+			[]string{"Fed", "Petted", "NoLongerHungry", "NoLongerLonely"}),
+		Entry("capping love by the stage capacity",
+			engine.State{Food: 50, Love: 95, Health: 100},
+			0, 10,
+			engine.State{Food: 50, Love: 100, Health: 100, Stage: engine.Adult},
+			[]string{"Petted"}),
+		Entry("overfeeding pets fed beyond their capacity",
+			engine.State{Food: 70, Love: 50, Health: 100, Stage: engine.Baby},
+			30, 0,
+			engine.State{Food: 80, Love: 50, Health: 100, Stage: engine.Baby, Overfeedings: 1,
+				OverfedUntil: now.Add(5 * time.Minute), Conditions: conditions(engine.Overfed)},
+			[]string{"Fed", "Overfed"}),
+		Entry("costing health when overfed pets are overfed again",
+			engine.State{Food: 90, Love: 50, Health: 100, Overfeedings: 1,
+				OverfedUntil: now.Add(time.Minute), Conditions: conditions(engine.Overfed)},
+			20, 0,
+			engine.State{Food: 100, Love: 50, Health: 90, Overfeedings: 2, Stage: engine.Adult,
+				OverfedUntil: now.Add(5 * time.Minute), Conditions: conditions(engine.Overfed)},
+			[]string{"Fed", "Overeating"}),
+		Entry("reducing the love overfed pets gain",
+			engine.State{Food: 90, Love: 50, Health: 100,
+				OverfedUntil: now.Add(time.Minute), Conditions: conditions(engine.Overfed)},
+			0, 10,
+			engine.State{Food: 90, Love: 55, Health: 100, Stage: engine.Adult,
+				OverfedUntil: now.Add(time.Minute), Conditions: conditions(engine.Overfed)},
+			[]string{"Petted"}),
+		Entry("ignoring amounts that are not positive",
+			engine.State{Food: 50, Love: 50, Health: 100},
+			-10, 10,
+			engine.State{Food: 50, Love: 60, Health: 100, Stage: engine.Adult},
+			[]string{"Petted"}),
+		Entry("ignoring dead pets",
+			engine.State{Conditions: conditions(engine.Dead, engine.Sick, engine.Hungry, engine.Lonely)},
+			30, 10,
+			engine.State{Stage: engine.Adult, Conditions: conditions(engine.Dead, engine.Sick, engine.Hungry, engine.Lonely)},
+			nil),
+	)
+})
+
+var _ = Describe("Heal", func() {
+	DescribeTable("should restore health",
+		func(before engine.State, health int, want engine.State, wantReasons []string) {
+			s, events := engine.Heal(adult(before), rules, now, health)
+			Expect(s).To(Equal(want))
+			Expect(reasons(events)).To(Equal(wantReasons))
+		},
+		Entry("ending neglect and sickness",
+			engine.State{Food: 80, Love: 80, Health: 40, NeglectedTicks: 4, Conditions: conditions(engine.Sick)},
+			20,
+			engine.State{Food: 80, Love: 80, Health: 60, Stage: engine.Adult},
+			[]string{"Treated", "Recovered"}),
+		Entry("up to the maximum health",
+			engine.State{Food: 80, Love: 80, Health: 90},
+			50,
+			engine.State{Food: 80, Love: 80, Health: engine.MaxHealth, Stage: engine.Adult},
+			[]string{"Treated"}),
+	)
+})
+
+var _ = Describe("Grieve", func() {
+	DescribeTable("should cost love for every lost friend",
+		func(before engine.State, lost []string, want engine.State, wantReasons []string) {
+			s, events := engine.Grieve(adult(before), rules, now, lost)
+			Expect(s).To(Equal(want))
+			Expect(reasons(events)).To(Equal(wantReasons))
+		},
+		Entry("of living pets",
+			engine.State{Food: 80, Love: 80, Health: 100},
+			[]string{"a", "b"},
+			engine.State{Food: 80, Love: 40, Health: 100, Stage: engine.Adult},
+			[]string{"Grieving", "Grieving"}),
+		Entry("down to loneliness",
+			engine.State{Food: 80, Love: 10, Health: 100},
+			[]string{"a"},
+			engine.State{Food: 80, Health: 100, Stage: engine.Adult, Conditions: conditions(engine.Lonely)},
+			[]string{"Grieving", "NeedLove"}),
+		Entry("but not of dead pets",
+			engine.State{Food: 80, Love: 80, Conditions: conditions(engine.Dead, engine.Sick)},
+			[]string{"a"},
+			engine.State{Food: 80, Love: 80, Stage: engine.Adult, Conditions: conditions(engine.Dead, engine.Sick)},
+			nil),
+	)
+})
+
+var _ = Describe("Revive", func() {
+	It("should restart dead pets with their initial values", func() {
+		pet := adult(engine.State{Stage: engine.Senior, NeglectedTicks: 7, Ticks: 20,
+			Conditions: conditions(engine.Dead, engine.Sick, engine.Hungry, engine.Lonely)})
+		s, events := engine.Revive(pet, rules, now)
+		Expect(s).To(Equal(engine.State{Food: 80, Love: 100, Health: 100, Stage: engine.Senior, Ticks: 20}))
+		Expect(reasons(events)).To(Equal([]string{"Recovered", "NoLongerHungry", "NoLongerLonely"}))
+	})
+})
+
+var _ = Describe("FriendshipBonus", func() {
+	happy := engine.State{Love: 80}
+	sad := engine.State{Love: 10}
+	dead := engine.State{Love: 80, Conditions: conditions(engine.Dead)}
+
+	DescribeTable("should count happy living friends",
+		func(pet engine.State, friends []engine.State, bonus int) {
+			Expect(engine.FriendshipBonus(pet, friends, rules)).To(Equal(bonus))
+		},
+		Entry("of happy pets", happy, []engine.State{happy, happy, sad, dead}, 4),
+		Entry("but not of unhappy pets", sad, []engine.State{happy}, 0),
+		Entry("without friends", happy, nil, 0),
+	)
+})
+
+var _ = Describe("MoodOf", func() {
+	DescribeTable("should judge how pets feel",
+		func(s engine.State, mood engine.Mood) {
+			Expect(engine.MoodOf(s)).To(Equal(mood))
+		},
+		Entry("dead", engine.State{Food: 100, Love: 100, Conditions: conditions(engine.Dead, engine.Sick)}, engine.MoodDead),
+		Entry("sick", engine.State{Food: 100, Love: 100, Conditions: conditions(engine.Sick, engine.Overfed)}, engine.MoodSick),
+		Entry("overfed", engine.State{Food: 100, Love: 100, Conditions: conditions(engine.Overfed)}, engine.MoodOverfed),
+		Entry("furious", engine.State{Food: 29}, engine.MoodFurious),
+		Entry("heartbroken", engine.State{Food: 30}, engine.MoodHeartbroken),
+		Entry("angry", engine.State{Food: 100, Love: 29}, engine.MoodAngry),
+		Entry("sad", engine.State{Food: 49, Love: 100}, engine.MoodSad),
+		Entry("adoring", engine.State{Food: 81, Love: 91}, engine.MoodAdoring),
+		Entry("happy", engine.State{Food: 80, Love: 80}, engine.MoodHappy),
+		Entry("content", engine.State{Food: 50, Love: 79}, engine.MoodContent),
+	)
+})
@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/itzloop/pet-controller/pkg/engine"
+)
+
+var stages = []engine.Stage{"", engine.Baby, engine.Adult, engine.Senior}
+
+// fuzzPet builds a valid pet from arbitrary fuzzer input.
+func fuzzPet(food, love, health, neglected, stage uint8, ticks, cared uint16, lived, overfed int64) engine.Pet {
+	s := engine.State{
+		Food:           int(food) % 101,
+		Love:           int(love) % 101,
+		Health:         int(health) % 101,
+		NeglectedTicks: int(neglected),
+		Stage:          stages[int(stage)%len(stages)],
+		Ticks:          int(ticks),
+		CaredTicks:     int(cared) % (int(ticks) + 1),
+		OverfedUntil:   now.Add(time.Duration(overfed % int64(time.Hour))),
+	}
+	s, _ = engine.Evaluate(engine.Pet{State: s}, rules, now)
+
+	return engine.Pet{
+		Nickname:      "Rex",
+		FoodDecayRate: 2,
+		LoveDecayRate: 3,
+		Born:          now.Add(-time.Duration(lived % int64(100*time.Hour)).Abs()),
+		State:         s,
+	}
+}
+
+// checkInvariants fails t when s breaks a rule every state must follow.
+func checkInvariants(t *testing.T, s engine.State) {
+	maxFood, maxLove := rules.Capacity(s.Stage)
+	if s.Food < 0 || s.Food > maxFood {
+		t.Errorf("food %d out of [0, %d]", s.Food, maxFood)
+	}
+	if s.Love < 0 || s.Love > maxLove {
+		t.Errorf("love %d out of [0, %d]", s.Love, maxLove)
+	}
+	if s.Health < 0 || s.Health > engine.MaxHealth {
+		t.Errorf("health %d out of [0, %d]", s.Health, engine.MaxHealth)
+	}
+	if s.CaredTicks > s.Ticks {
+		t.Errorf("cared for %d of %d ticks", s.CaredTicks, s.Ticks)
+	}
+	if s.Is(engine.Dead) != (s.Health == 0) {
+		t.Errorf("dead is %v with health %d", s.Is(engine.Dead), s.Health)
+	}
+	if s.Is(engine.Hungry) != (s.Food < rules.HungryThreshold) {
+		t.Errorf("hungry is %v with food %d", s.Is(engine.Hungry), s.Food)
+	}
+	if s.Is(engine.Lonely) != (s.Love == 0) {
+		t.Errorf("lonely is %v with love %d", s.Is(engine.Lonely), s.Love)
+	}
+}
+
+func FuzzDecay(f *testing.F) {
+	f.Add(uint8(80), uint8(80), uint8(100), uint8(0), uint8(2), uint16(0), uint16(0), int64(time.Hour), int64(0), uint8(0))
+	f.Add(uint8(0), uint8(0), uint8(5), uint8(9), uint8(1), uint16(10), uint16(10), int64(time.Hour), int64(time.Minute), uint8(8))
+	f.Add(uint8(100), uint8(100), uint8(100), uint8(0), uint8(3), uint16(500), uint16(1), int64(90*time.Hour), int64(0), uint8(100))
+
+	f.Fuzz(func(t *testing.T, food, love, health, neglected, stage uint8, ticks, cared uint16, lived, overfed int64, bonus uint8) {
+		pet := fuzzPet(food, love, health, neglected, stage, ticks, cared, lived, overfed)
+		s, events := engine.Decay(pet, rules, now, int(bonus))
+
+		checkInvariants(t, s)
+		if s.Ticks != pet.Ticks+1 {
+			t.Errorf("ticks went from %d to %d", pet.Ticks, s.Ticks)
+		}
+		if slices.Index(stages, s.Stage) < slices.Index(stages, pet.Stage) {
+			t.Errorf("pet grew younger from %s to %s", pet.Stage, s.Stage)
+		}
+		for _, e := range events {
+			if s.Is(engine.Dead) && e.Reason != "Dead" && e.Reason != "GrewUp" {
+				t.Errorf("dead pet reported %s", e.Reason)
+			}
+		}
+	})
+}
+
+func FuzzAct(f *testing.F) {
+	f.Add(uint8(50), uint8(50), uint8(100), uint8(2), int64(0), 30, 10)
+	f.Add(uint8(90), uint8(50), uint8(100), uint8(2), int64(time.Minute), 50, 0)
+	f.Add(uint8(0), uint8(0), uint8(0), uint8(1), int64(0), 100, 100)
+	f.Add(uint8(30), uint8(30), uint8(60), uint8(3), int64(0), -5, -5)
+
+	f.Fuzz(func(t *testing.T, food, love, health, stage uint8, overfed int64, feed, pet int) {
+		before := fuzzPet(food, love, health, 0, stage, 0, 0, 0, overfed)
+		// 🐾 Start within the capacity of the stage, as pets always do
+		maxFood, maxLove := rules.Capacity(before.Stage)
+		before.Food, before.Love = min(before.Food, maxFood), min(before.Love, maxLove)
+		before.State, _ = engine.Evaluate(before, rules, now)
+
+		s, _ := engine.Act(before, rules, now, feed, pet)
+
+		checkInvariants(t, s)
+		if before.Is(engine.Dead) && s != before.State {
+			t.Errorf("dead pet changed from %+v to %+v", before.State, s)
+		}
+		if s.Food < before.Food || s.Love < before.Love {
+			t.Errorf("actions took food or love, %d/%d → %d/%d", before.Food, before.Love, s.Food, s.Love)
+		}
+		if s.Health > before.Health {
+			t.Errorf("actions healed the pet from %d to %d", before.Health, s.Health)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kube converts pets between their Kubernetes representation and the
+// game engine.
+package kube
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/pkg/engine"
+)
+
+// Pet returns pet as the engine sees it.
+func Pet(pet *linuxfestv2025.Pet) engine.Pet {
+	return engine.Pet{
+		Nickname:      pet.Spec.Nickname,
+		FoodDecayRate: pet.Spec.FoodDecayRate,
+		LoveDecayRate: pet.Spec.LoveDecayRate,
+		Born:          pet.CreationTimestamp.Time,
+		State:         State(&pet.Status),
+	}
+}
+
+// State returns the engine state stored in status.
+func State(status *linuxfestv2025.PetStatus) engine.State {
+	s := engine.State{
+		Food:           status.Food,
+		Love:           status.Love,
+		Health:         status.Health,
+		NeglectedTicks: status.NeglectedTicks,
+		Stage:          engine.Stage(status.Stage),
+		Ticks:          status.Ticks,
+		CaredTicks:     status.CaredTicks,
+		Overfeedings:   status.Overfeedings,
+		OverfedUntil:   status.OverfedUntil.Time,
+	}
+	for _, c := range engine.AllConditions {
+		s.Conditions = s.Conditions.With(c, meta.IsStatusConditionTrue(status.Conditions, c.String()))
+	}
+	return s
+}
+
+// Apply stores s in pet's status. Conditions that changed are stamped with now.
+func Apply(pet *linuxfestv2025.Pet, s engine.State, now time.Time) {
+	status := &pet.Status
+	status.Food = s.Food
+	status.Love = s.Love
+	status.Health = s.Health
+	status.NeglectedTicks = s.NeglectedTicks
+	status.Stage = linuxfestv2025.PetStage(s.Stage)
+	status.Ticks = s.Ticks
+	status.CaredTicks = s.CaredTicks
+	status.Overfeedings = s.Overfeedings
+	status.OverfedUntil = metav1.NewTime(s.OverfedUntil)
+
+	for _, c := range engine.AllConditions {
+		cond := metav1.Condition{
+			Type:               c.String(),
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: pet.Generation,
+			Reason:             "Fine",
+			Message:            fmt.Sprintf("food %d, love %d, health %d", s.Food, s.Love, s.Health),
+			LastTransitionTime: metav1.NewTime(now),
+		}
+		if s.Is(c) {
+			cond.Status = metav1.ConditionTrue
+			cond.Reason = c.String()
+		}
+		meta.SetStatusCondition(&status.Conditions, cond)
+	}
+}
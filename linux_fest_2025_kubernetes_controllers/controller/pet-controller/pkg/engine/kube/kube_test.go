@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/pkg/engine"
+)
+
+var _ = Describe("Apply", func() {
+	var (
+		now time.Time
+		pet *linuxfestv2025.Pet
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2025, 4, 26, 12, 0, 0, 0, time.UTC)
+		pet = &linuxfestv2025.Pet{
+			ObjectMeta: metav1.ObjectMeta{Generation: 3, CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+			Spec:       linuxfestv2025.PetSpec{Nickname: "Rex", FoodDecayRate: 2, LoveDecayRate: 3},
+		}
+	})
+
+	It("should round trip the state through the pet's status", func() {
+		s := engine.State{
+			Food: 10, Love: 0, Health: 40, NeglectedTicks: 4, Stage: engine.Adult,
+			Ticks: 9, CaredTicks: 5, Overfeedings: 2, OverfedUntil: now.Add(time.Minute),
+		}
+		s.Conditions = s.Conditions.With(engine.Sick, true).With(engine.Hungry, true).With(engine.Lonely, true)
+
+		Apply(pet, s, now)
+		Expect(Pet(pet)).To(Equal(engine.Pet{
+			Nickname: "Rex", FoodDecayRate: 2, LoveDecayRate: 3, Born: now.Add(-time.Hour), State: s,
+		}))
+		Expect(pet.Status.Stage).To(Equal(linuxfestv2025.PetStageAdult))
+	})
+
+	It("should write every condition and stamp only the ones that changed", func() {
+		Apply(pet, engine.State{Food: 10, Love: 50, Health: 100}, now)
+		Expect(pet.Status.Conditions).To(HaveLen(len(engine.AllConditions)))
+
+		hungry := meta.FindStatusCondition(pet.Status.Conditions, linuxfestv2025.PetConditionHungry)
+		Expect(hungry.Status).To(Equal(metav1.ConditionFalse))
+		Expect(hungry.ObservedGeneration).To(Equal(int64(3)))
+
+		s := State(&pet.Status)
+		s.Conditions = s.Conditions.With(engine.Hungry, true)
+		Apply(pet, s, now.Add(time.Minute))
+
+		hungry = meta.FindStatusCondition(pet.Status.Conditions, linuxfestv2025.PetConditionHungry)
+		Expect(hungry.Status).To(Equal(metav1.ConditionTrue))
+		Expect(hungry.Reason).To(Equal(linuxfestv2025.PetConditionHungry))
+		Expect(hungry.LastTransitionTime.Time).To(Equal(now.Add(time.Minute)))
+
+		lonely := meta.FindStatusCondition(pet.Status.Conditions, linuxfestv2025.PetConditionLonely)
+		Expect(lonely.LastTransitionTime.Time).To(Equal(now))
+	})
+})
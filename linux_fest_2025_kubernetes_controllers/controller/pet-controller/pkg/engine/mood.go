@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+// Mood is how a pet feels.
+type Mood string
+
+// Moods a pet can be in, from worst to best.
+const (
+	MoodDead        Mood = "Dead"
+	MoodSick        Mood = "Sick"
+	MoodOverfed     Mood = "Overfed"
+	MoodFurious     Mood = "Furious"
+	MoodHeartbroken Mood = "Heartbroken"
+	MoodAngry       Mood = "Angry"
+	MoodSad         Mood = "Sad"
+	MoodContent     Mood = "Content"
+	MoodHappy       Mood = "Happy"
+	MoodAdoring     Mood = "Adoring"
+)
+
+// Food and love levels moods are judged by.
+const (
+	moodStarving = 30
+	moodUnhappy  = 50
+	moodHappy    = 80
+	moodAdoring  = 90
+)
+
+// MoodOf returns the mood of a pet in state s. Conditions trump food and love.
+func MoodOf(s State) Mood {
+	switch {
+	case s.Is(Dead):
+		return MoodDead
+	case s.Is(Sick):
+		return MoodSick
+	case s.Is(Overfed):
+		return MoodOverfed
+	case s.Food < moodStarving && s.Love == 0:
+		return MoodFurious
+	case s.Love == 0:
+		return MoodHeartbroken
+	case s.Food < moodStarving || s.Love < moodStarving:
+		return MoodAngry
+	case s.Food < moodUnhappy || s.Love < moodUnhappy:
+		return MoodSad
+	case s.Love > moodAdoring && s.Food > moodHappy:
+		return MoodAdoring
+	case s.Food >= moodHappy && s.Love >= moodHappy:
+		return MoodHappy
+	default:
+		return MoodContent
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import "time"
+
+// MaxHealth is the most health a pet can have.
+const MaxHealth = 100
+
+// Rules are the tunables of the game.
+type Rules struct {
+	// InitialFood is the food a pet starts with.
+	InitialFood int
+
+	// InitialLove is the love a pet starts with.
+	InitialLove int
+
+	// MaxFood caps the food of every pet.
+	MaxFood int
+
+	// MaxLove caps the love of every pet.
+	MaxLove int
+
+	// HungryThreshold is the food level below which a pet is hungry.
+	HungryThreshold int
+
+	Health      HealthRules
+	Stages      StageRules
+	Social      SocialRules
+	Overfeeding OverfeedingRules
+}
+
+// HealthRules are the tunables of pet health and sickness.
+type HealthRules struct {
+	// Initial is the health a pet starts with.
+	Initial int
+
+	// FoodThreshold is the food level below which a pet is neglected.
+	FoodThreshold int
+
+	// LoveThreshold is the love level below which a pet is neglected.
+	LoveThreshold int
+
+	// NeglectIntervals is the number of consecutive neglected decay intervals
+	// after which a pet starts losing health.
+	NeglectIntervals int
+
+	// DecayRate is the health a neglected pet loses every decay interval.
+	DecayRate int
+
+	// RecoveryRate is the health a cared for pet regains every decay interval.
+	RecoveryRate int
+
+	// SickThreshold is the health below which a pet is sick.
+	SickThreshold int
+}
+
+// StageRules are the tunables of the life stages.
+type StageRules struct {
+	// AdultAfter is the lifetime after which a baby grows up.
+	AdultAfter time.Duration
+
+	// AdultMinCare is the care score a baby needs to grow up.
+	AdultMinCare int
+
+	// SeniorAfter is the lifetime after which an adult with a care score of 50
+	// becomes a senior.
+	SeniorAfter time.Duration
+
+	Baby   StageRule
+	Adult  StageRule
+	Senior StageRule
+}
+
+// StageRule are the tunables of a single life stage.
+type StageRule struct {
+	// DecayMultiplier scales the food and love decay rates.
+	DecayMultiplier float64
+
+	// MaxFood caps the food of pets in this stage.
+	MaxFood int
+
+	// MaxLove caps the love of pets in this stage.
+	MaxLove int
+}
+
+// SocialRules are the tunables of friendships between pets.
+type SocialRules struct {
+	// FriendshipThreshold is the love both friends need for their friendship to grow.
+	FriendshipThreshold int
+
+	// FriendshipBonus is the love a pet gains every decay interval from each happy friend.
+	FriendshipBonus int
+
+	// GriefPenalty is the love a pet loses when one of its friends dies.
+	GriefPenalty int
+}
+
+// OverfeedingRules are the consequences of feeding a pet beyond a cap.
+type OverfeedingRules struct {
+	// Cap is the food level feeding may fill a pet up to.
+	Cap int
+
+	// Duration is how long a pet stays overfed.
+	Duration time.Duration
+
+	// LoveGainMultiplier scales the love an overfed pet gains from petting.
+	LoveGainMultiplier float64
+
+	// DecayMultiplier scales the food decay rate of an overfed pet.
+	DecayMultiplier float64
+
+	// HealthPenalty is the health a pet loses when it is overfed while still overfed.
+	HealthPenalty int
+}
+
+// stage returns the rules of stage. Pets without a stage are babies.
+func (r StageRules) stage(stage Stage) StageRule {
+	switch stage {
+	case Adult:
+		return r.Adult
+	case Senior:
+		return r.Senior
+	default:
+		return r.Baby
+	}
+}
+
+// Capacity returns the most food and love a pet in stage can hold.
+func (r Rules) Capacity(stage Stage) (food, love int) {
+	rule := r.Stages.stage(stage)
+	return min(rule.MaxFood, r.MaxFood), min(rule.MaxLove, r.MaxLove)
+}
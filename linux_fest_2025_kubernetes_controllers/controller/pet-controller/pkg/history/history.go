@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package history stores the food, love and health samples of a pet in a
+// ConfigMap owned by the pet, so clients can draw trends across restarts.
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+)
+
+const (
+	// DataKey is the ConfigMap key holding the JSON encoded samples, oldest first.
+	DataKey = "samples.json"
+
+	// PetLabel is set on history ConfigMaps to the name of their pet.
+	PetLabel = "linuxfest.example.com/pet"
+)
+
+// Sample is the state of a pet after a change.
+type Sample struct {
+	Time   metav1.Time `json:"time"`
+	Food   int         `json:"food"`
+	Love   int         `json:"love"`
+	Health int         `json:"health"`
+
+	// Cause is what changed the pet, e.g. Decay or Fed.
+	Cause string `json:"cause"`
+}
+
+// ConfigMapName is the name of the ConfigMap holding the history of the pet named pet.
+func ConfigMapName(pet string) string {
+	return pet + "-history"
+}
+
+// Append adds sample to samples and drops the oldest samples so at most size remain.
+func Append(samples []Sample, sample Sample, size int) []Sample {
+	samples = append(samples, sample)
+	if len(samples) > size {
+		samples = samples[len(samples)-size:]
+	}
+	return samples
+}
+
+// Decode returns the samples stored in cm.
+func Decode(cm *corev1.ConfigMap) ([]Sample, error) {
+	data, ok := cm.Data[DataKey]
+	if !ok || data == "" {
+		return nil, nil
+	}
+
+	var samples []Sample
+	if err := json.Unmarshal([]byte(data), &samples); err != nil {
+		return nil, fmt.Errorf("decoding history %s: %w", cm.Name, err)
+	}
+	return samples, nil
+}
+
+// encode stores samples in cm.
+func encode(cm *corev1.ConfigMap, samples []Sample) error {
+	data, err := json.Marshal(samples)
+	if err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[DataKey] = string(data)
+	return nil
+}
+
+// Get returns the history of the pet named key, oldest first. Pets without a
+// history have no samples.
+func Get(ctx context.Context, c client.Reader, key client.ObjectKey) ([]Sample, error) {
+	var cm corev1.ConfigMap
+	if err := c.Get(ctx, client.ObjectKey{Namespace: key.Namespace, Name: ConfigMapName(key.Name)}, &cm); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return Decode(&cm)
+}
+
+// Recorder appends samples to the history ConfigMaps of pets.
+type Recorder struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// Record appends sample to the history of pet, keeping at most size samples.
+// The ConfigMap is created on first use and owned by pet so it is garbage
+// collected with it.
+func (r *Recorder) Record(ctx context.Context, pet *linuxfestv2025.Pet, sample Sample, size int) error {
+	// 🔁 A cached client may not have seen a ConfigMap created by a previous call yet
+	retriable := func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}
+
+	return retry.OnError(retry.DefaultRetry, retriable, func() error {
+		var cm corev1.ConfigMap
+		err := r.Get(ctx, client.ObjectKey{Namespace: pet.Namespace, Name: ConfigMapName(pet.Name)}, &cm)
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+
+		if err != nil {
+			cm = corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: pet.Namespace,
+					Name:      ConfigMapName(pet.Name),
+					Labels:    map[string]string{PetLabel: pet.Name},
+				},
+			}
+			if err := controllerutil.SetControllerReference(pet, &cm, r.Scheme); err != nil {
+				return err
+			}
+			if err := encode(&cm, []Sample{sample}); err != nil {
+				return err
+			}
+			return r.Create(ctx, &cm)
+		}
+
+		samples, err := Decode(&cm)
+		if err != nil {
+			// 🧹 Start over rather than getting stuck on a corrupted history
+			samples = nil
+		}
+
+		cpy := cm.DeepCopy()
+		if err := encode(cpy, Append(samples, sample, size)); err != nil {
+			return err
+		}
+		return r.Update(ctx, cpy)
+	})
+}
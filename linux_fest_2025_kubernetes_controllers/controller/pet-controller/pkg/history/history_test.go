@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	linuxfestv2025 "github.com/itzloop/pet-controller/api/v2025"
+)
+
+func sample(food int) Sample {
+	return Sample{Time: metav1.NewTime(time.Unix(int64(food), 0)), Food: food, Cause: "Decay"}
+}
+
+var _ = Describe("Append", func() {
+	It("should drop the oldest samples once full", func() {
+		var samples []Sample
+		for food := range 5 {
+			samples = Append(samples, sample(food), 3)
+		}
+		Expect(samples).To(Equal([]Sample{sample(2), sample(3), sample(4)}))
+	})
+})
+
+var _ = Describe("Recorder", func() {
+	var (
+		ctx      context.Context
+		c        client.Client
+		recorder *Recorder
+		pet      *linuxfestv2025.Pet
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(linuxfestv2025.AddToScheme(scheme)).To(Succeed())
+
+		pet = &linuxfestv2025.Pet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "rex", UID: "rex-uid"}}
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(pet).Build()
+		recorder = &Recorder{Client: c, Scheme: scheme}
+	})
+
+	It("should create a ConfigMap owned by the pet", func() {
+		Expect(recorder.Record(ctx, pet, sample(90), 3)).To(Succeed())
+
+		samples, err := Get(ctx, c, client.ObjectKeyFromObject(pet))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(samples).To(HaveLen(1))
+		Expect(samples[0].Food).To(Equal(90))
+
+		var cm corev1.ConfigMap
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "default", Name: ConfigMapName(pet.Name)}, &cm)).To(Succeed())
+		Expect(cm.Labels).To(HaveKeyWithValue(PetLabel, pet.Name))
+		Expect(cm.OwnerReferences).To(ConsistOf(HaveField("UID", pet.UID)))
+	})
+
+	It("should keep a bounded history", func() {
+		for food := range 5 {
+			Expect(recorder.Record(ctx, pet, sample(food), 3)).To(Succeed())
+		}
+
+		samples, err := Get(ctx, c, client.ObjectKeyFromObject(pet))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(samples).To(Equal([]Sample{sample(2), sample(3), sample(4)}))
+	})
+
+	It("should return no samples for pets without a history", func() {
+		samples, err := Get(ctx, c, client.ObjectKey{Namespace: "default", Name: "ghost"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(samples).To(BeEmpty())
+	})
+})
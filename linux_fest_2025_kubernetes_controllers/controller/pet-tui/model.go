@@ -11,31 +11,83 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	v2025 "github.com/itzloop/pet-controller/api/v2025"
+	"github.com/itzloop/pet-controller/pkg/engine"
+	"github.com/itzloop/pet-controller/pkg/engine/kube"
+	"github.com/itzloop/pet-controller/pkg/history"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type Pet v2025.Pet
 
+// Dead reports whether the controller declared the pet dead.
+func (p Pet) Dead() bool {
+	return meta.IsStatusConditionTrue(p.Status.Conditions, v2025.PetConditionDead)
+}
+
+// moodEmoji is the emoji shown for every mood of a pet.
+var moodEmoji = map[engine.Mood]string{
+	engine.MoodDead:        "💀",
+	engine.MoodSick:        "🤒",
+	engine.MoodOverfed:     "🤢",
+	engine.MoodFurious:     "🤬",
+	engine.MoodHeartbroken: "😭",
+	engine.MoodAngry:       "😠",
+	engine.MoodSad:         "😢",
+	engine.MoodAdoring:     "🥰",
+	engine.MoodHappy:       "😍",
+	engine.MoodContent:     "🙂",
+}
+
+// Emoji shows how the pet feels, the engine decides its mood.
 func (p Pet) Emoji() string {
-	switch {
-	case p.Status.Food == 0:
-		return "💀"
-	case p.Status.Food < 30 && p.Status.Love == 0:
-		return "🤬"
-	case p.Status.Food >= 30 && p.Status.Love == 0:
-		return "😭"
-	case p.Status.Food < 30 || p.Status.Love < 30:
-		return "😠"
-	case p.Status.Food < 50 || p.Status.Love < 50:
-		return "😢"
-	case p.Status.Love > 90 && p.Status.Food > 80:
-		return "🥰"
-	case p.Status.Food >= 80 && p.Status.Love >= 80:
-		return "😍"
+	pet := v2025.Pet(p)
+	return moodEmoji[engine.MoodOf(kube.State(&pet.Status))]
+}
+
+// Cooldown is how long the controller keeps ignoring feed and pet requests
+// after the pet or its caretaker exceeded a rate limit.
+func (p Pet) Cooldown() time.Duration {
+	return time.Until(p.Status.CooldownUntil.Time).Round(time.Second)
+}
+
+// Art is a small drawing of the pet that changes as it grows older.
+func (p Pet) Art() string {
+	eyes := "o o"
+	if p.Dead() {
+		eyes = "x x"
+	}
+
+	switch p.Status.Stage {
+	case v2025.PetStageAdult:
+		return fmt.Sprintf(" /\\_/\\ \n( %s )\n > ^ < ", eyes)
+	case v2025.PetStageSenior:
+		return fmt.Sprintf(" /\\_/\\ \n(-%s-)\n/>~~~<\\", eyes)
 	default:
-		return "🙂"
+		return fmt.Sprintf("  ___  \n (%s) \n  \"\"\"  ", eyes)
+	}
+}
+
+// badges are the emojis shown for the achievements of pets and caretakers.
+var badges = map[string]string{
+	"NoHunger7d":  "🥇",
+	"Survivor30d": "🛡️",
+	"Fed100":      "🍖",
+	"Petted100":   "💞",
+}
+
+// Badges renders the achievements of a pet or caretaker.
+func Badges(achievements []string) string {
+	var b strings.Builder
+	for _, achievement := range achievements {
+		if badge, ok := badges[achievement]; ok {
+			b.WriteString(badge)
+		} else {
+			b.WriteString("🏅")
+		}
 	}
+	return b.String()
 }
 
 // Define the model struct in one place
@@ -44,6 +96,12 @@ type model struct {
 	pets   []v2025.Pet
 	cursor int
 	err    error
+
+	// trend is the history of the selected pet, it is empty unless the controller records history.
+	trend []history.Sample
+
+	// leaderboard ranks the caretakers of the selected pet's namespace.
+	leaderboard *v2025.Leaderboard
 }
 
 func New(k8s client.Client) tea.Model {
@@ -68,12 +126,45 @@ func (m model) View() string {
 		}
 
 		pet := Pet(p)
-		fmt.Fprintf(&b, "%s %s  %s\n", cursor, pet.Emoji(), lipgloss.NewStyle().Bold(true).Render(p.Spec.Nickname))
-		fmt.Fprintf(&b, "   🍗 Food: %s  (%d)\n", bar(pet.Status.Food), pet.Status.Food)
-		fmt.Fprintf(&b, "   ❤️ Love: %s  (%d)\n\n", bar(pet.Status.Love), pet.Status.Love)
+		stage := string(pet.Status.Stage)
+		if stage == "" {
+			stage = string(v2025.PetStageBaby)
+		}
+		cooldown := ""
+		if d := pet.Cooldown(); d > 0 {
+			cooldown = fmt.Sprintf("⏳ %s", d)
+		}
+		fmt.Fprintf(&b, "%s %s  %s  %s  %s  %s\n", cursor, pet.Emoji(),
+			lipgloss.NewStyle().Bold(true).Render(p.Spec.Nickname),
+			lipgloss.NewStyle().Faint(true).Render(stage),
+			Badges(pet.Status.Achievements), cooldown)
+		stats := fmt.Sprintf("🍗 Food: %s  (%d)\n❤️ Love: %s  (%d)\n🩺 Life: %s  (%d)",
+			bar(pet.Status.Food), pet.Status.Food, bar(pet.Status.Love), pet.Status.Love,
+			bar(pet.Status.Health), pet.Status.Health)
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Center,
+			lipgloss.NewStyle().MarginLeft(3).MarginRight(2).Render(pet.Art()), stats))
+		b.WriteString("\n")
+		if i == m.cursor && len(m.trend) > 0 {
+			food := make([]int, len(m.trend))
+			love := make([]int, len(m.trend))
+			for j, sample := range m.trend {
+				food[j], love[j] = sample.Food, sample.Love
+			}
+			fmt.Fprintf(&b, "   📈 Food %s\n", sparkline(food, 40))
+			fmt.Fprintf(&b, "   📈 Love %s\n", sparkline(love, 40))
+		}
+		b.WriteString("\n")
 	}
-	b.WriteString("⬆⬇: Move 🧭  |  f: Feed  🍗  |  l: Love  ❤️  |  q: Quit ❌\n")
-	b.WriteString("             |  F: 🍗🍗🍗🍗  |  L: ❤️❤️❤️❤️  |            \n")
+	if m.leaderboard != nil && len(m.leaderboard.Status.Caretakers) > 0 {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("🏆 Top caretakers") + "\n")
+		for i, caretaker := range m.leaderboard.Status.Caretakers[:min(3, len(m.leaderboard.Status.Caretakers))] {
+			fmt.Fprintf(&b, "   %d. %s  🍗 %d  ❤️ %d  %s\n", i+1, caretaker.Name,
+				caretaker.Feedings, caretaker.Pettings, Badges(caretaker.Achievements))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("⬆⬇: Move 🧭  |  f: Feed  🍗  |  l: Love  ❤️  |  r: Revive 🪄  |  q: Quit ❌\n")
+	b.WriteString("             |  F: 🍗🍗🍗🍗  |  L: ❤️❤️❤️❤️  |                |            \n")
 	return b.String()
 }
 
@@ -126,6 +217,25 @@ func (m model) UpdatePet(petName string, ns string, deltaFood, deltaPet int) err
 	return err
 }
 
+// RevivePet asks the controller to revive a dead pet, its household pays for it.
+func (m model) RevivePet(petName string, ns string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ctx := context.Background()
+
+		var pet v2025.Pet
+		if err := m.k8s.Get(ctx, client.ObjectKey{Name: petName, Namespace: ns}, &pet); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		petCopy := pet.DeepCopy()
+		if petCopy.Annotations == nil {
+			petCopy.Annotations = map[string]string{}
+		}
+		petCopy.Annotations["linuxfest.example.com/revive"] = "true"
+		return m.k8s.Update(ctx, petCopy)
+	})
+}
+
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tickMsg:
@@ -145,6 +255,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.cursor = len(m.pets) - 1
 		}
 
+		m.trend = nil
+		m.leaderboard = nil
+		if len(m.pets) > 0 {
+			// a missing history or leaderboard is only not shown
+			selected := m.pets[m.cursor]
+			m.trend, _ = history.Get(ctx, m.k8s, client.ObjectKeyFromObject(&selected))
+
+			var leaderboard v2025.Leaderboard
+			key := client.ObjectKey{Namespace: selected.Namespace, Name: v2025.LeaderboardName}
+			if err := m.k8s.Get(ctx, key, &leaderboard); err == nil {
+				m.leaderboard = &leaderboard
+			}
+		}
+
 		// do this one second later
 		return m, tea.Tick(1*time.Second, func(time.Time) tea.Msg { return tickMsg{} })
 	case errMsg:
@@ -165,6 +289,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case "f", "F":
+			// the controller would ignore the request anyway
+			if len(m.pets) == 0 || Pet(m.pets[m.cursor]).Cooldown() > 0 {
+				break
+			}
 			var delta = 10
 			if msg.String() == "F" {
 				delta = 100
@@ -177,7 +305,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if err := m.UpdatePet(pet.Name, pet.Namespace, delta, 0); err != nil {
 				return m, func() tea.Msg { return errMsg{fmt.Errorf("failed to update pet: %w", err)} }
 			}
+		case "r":
+			if len(m.pets) == 0 || !Pet(m.pets[m.cursor]).Dead() {
+				break
+			}
+			pet := m.pets[m.cursor]
+			if err := m.RevivePet(pet.Name, pet.Namespace); err != nil {
+				return m, func() tea.Msg { return errMsg{fmt.Errorf("failed to revive pet: %w", err)} }
+			}
 		case "l", "L":
+			if len(m.pets) == 0 || Pet(m.pets[m.cursor]).Cooldown() > 0 {
+				break
+			}
 			var delta = 10
 			if msg.String() == "F" {
 				delta = 100
@@ -7,3 +7,20 @@ func bar(val int) string {
 	full := val / 10
 	return strings.Repeat("█", full) + strings.Repeat("░", 10-full)
 }
+
+// sparkline draws the last width values between 0 and 100 as a line of block characters
+func sparkline(values []int, width int) string {
+	const levels = "▁▂▃▄▅▆▇█"
+	blocks := []rune(levels)
+
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		v = min(max(v, 0), 100)
+		b.WriteRune(blocks[v*(len(blocks)-1)/100])
+	}
+	return b.String()
+}